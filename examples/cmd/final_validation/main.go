@@ -110,7 +110,7 @@ func main() {
 
 			// Test direct pubkey verification
 			startTime := time.Now()
-			pubKeyResult, pubKeyErr = verify.EnhancedVerifyBip137SignatureWithPubKey(pubKey, vector.Message, vector.Signature)
+			pubKeyResult, pubKeyErr = verify.VerifyWithPubKey(pubKey, vector.Message, vector.Signature)
 			pubKeyDuration = time.Since(startTime)
 		} else {
 			fmt.Println("No public key provided, skipping direct pubkey verification")
@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/cryptopunkscc/bip-0137/verify"
-	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 )
 
 func main() {
@@ -30,7 +30,7 @@ func main() {
 	}
 
 	// Parse the public key from bytes
-	pubKey, err := secp256k1.ParsePubKey(pubKeyBytes)
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes)
 	if err != nil {
 		fmt.Printf("Error parsing public key: %v\n", err)
 		return
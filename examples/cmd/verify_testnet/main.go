@@ -0,0 +1,39 @@
+// Package main demonstrates verifying Bitcoin signatures for a testnet address.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/cryptopunkscc/bip-0137/verify"
+)
+
+func main() {
+	// Set log level to debug for detailed information
+	verify.SetLogLevel(verify.LogLevelDebug)
+
+	// Configure logging to stdout
+	verify.Logger.SetOutput(os.Stdout)
+
+	// A testnet P2PKH address (m/n prefix) and a signature made with its key
+	address := "muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz"
+	message := "Hello, Bitcoin testnet!"
+	signature := "H1iW5hS8TmYTjWSKywZrcYRfPiXT/LhoY+uqCYa64XqTP1oFtFTALrfpB1giJ3ks5z6Eax9xVogwNrlOnR5s2SI="
+
+	fmt.Println("============ VERIFYING TESTNET BITCOIN SIGNATURE ============")
+	fmt.Printf("Network:   %s\n", chaincfg.TestNet3Params.Name)
+	fmt.Printf("Address:   %s\n", address)
+	fmt.Printf("Message:   %s\n", message)
+	fmt.Printf("Signature: %s\n", signature)
+	fmt.Println("===========================================================")
+
+	// Testnet addresses must be decoded with the testnet parameters
+	valid, err := verify.VerifyBip137SignatureWithParams(address, message, signature, &chaincfg.TestNet3Params)
+	if err != nil {
+		fmt.Printf("\nVerification ERROR: %v\n", err)
+		return
+	}
+
+	fmt.Printf("\nVerification RESULT: %v\n", valid)
+}
@@ -0,0 +1,340 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// AddressType identifies the kind of address a signing key is committed to
+type AddressType int
+
+const (
+	AddressTypeP2PKH AddressType = iota
+	AddressTypeP2SHP2WPKH
+	AddressTypeP2WPKH
+)
+
+// addressTypeNames holds the display name of each address type
+var addressTypeNames = map[AddressType]string{
+	AddressTypeP2PKH:      "P2PKH",
+	AddressTypeP2SHP2WPKH: "P2SH-P2WPKH",
+	AddressTypeP2WPKH:     "P2WPKH",
+}
+
+// SupportedAddressTypes returns the address types BIP-0137 defines header
+// bytes for, in header byte order
+func SupportedAddressTypes() []AddressType {
+	return []AddressType{AddressTypeP2PKH, AddressTypeP2SHP2WPKH, AddressTypeP2WPKH}
+}
+
+// String returns the display name of the address type, e.g. "P2SH-P2WPKH"
+func (t AddressType) String() string {
+	if name, ok := addressTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("AddressType(%d)", int(t))
+}
+
+// ErrInvalidAddress is returned when an address cannot be decoded for the
+// requested network. The wrapping error includes a suggested correction when a
+// common typo is detected.
+var ErrInvalidAddress = errors.New("invalid bitcoin address")
+
+// ErrUnsupportedAddressType is returned when an address is valid but of a type
+// BIP-0137 does not define signatures for, such as P2TR
+var ErrUnsupportedAddressType = errors.New("unsupported address type")
+
+// ErrUncompressedSegwit is returned when a signature's header byte claims an
+// uncompressed key for a segwit address, which can only commit to a
+// compressed key
+var ErrUncompressedSegwit = errors.New("uncompressed key cannot sign for a segwit address")
+
+// ErrBadChecksum is returned when a base58 address fails its checksum, which
+// usually means it was mistyped
+var ErrBadChecksum = errors.New("bad base58check checksum")
+
+// knownNetworks lists the networks that are checked when an address does not
+// belong to the requested network.
+var knownNetworks = []*chaincfg.Params{
+	&chaincfg.MainNetParams,
+	&chaincfg.TestNet3Params,
+	&chaincfg.RegressionNetParams,
+	&chaincfg.SigNetParams,
+}
+
+// base58Confusables maps characters that are not part of the base58 alphabet
+// to the characters they are most commonly mistaken for.
+var base58Confusables = map[rune][]rune{
+	'0': {'o'},
+	'O': {'o'},
+	'I': {'1', 'i'},
+	'l': {'1', 'L'},
+}
+
+// ValidateAddress checks that address decodes for the network described by params.
+// When it does not, the returned error wraps ErrInvalidAddress and, where
+// possible, suggests a correction: a likely replacement for characters outside
+// the base58 alphabet, or the network the address actually belongs to.
+func ValidateAddress(address string, params *chaincfg.Params) error {
+	if address == "" {
+		return ErrEmptyAddress
+	}
+
+	addr, err := btcutil.DecodeAddress(address, params)
+	if err == nil && addr.IsForNet(params) {
+		return nil
+	}
+
+	// The address is well formed but belongs to another network
+	if network := findAddressNetwork(address); network != nil {
+		return fmt.Errorf("%w: address %s is for network %s, not %s (did you select the wrong network?)",
+			ErrInvalidAddress, address, network.Name, params.Name)
+	}
+
+	// Look for characters that are not valid base58
+	if suggestion, ok := suggestBase58Correction(address, params); ok {
+		return fmt.Errorf("%w: %s contains characters outside the base58 alphabet (did you mean %s?)",
+			ErrInvalidAddress, address, suggestion)
+	}
+	if i := strings.IndexFunc(address, isBase58Confusable); i >= 0 && !isBech32Address(address) {
+		return fmt.Errorf("%w: invalid base58 character %q at position %d", ErrInvalidAddress, address[i], i)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+	return fmt.Errorf("%w: address %s is not valid for network %s", ErrInvalidAddress, address, params.Name)
+}
+
+// ValidateBase58Check decodes a base58 address and verifies its 4-byte
+// checksum, independently of the network. It returns an error wrapping
+// ErrBadChecksum when the checksum does not match, and one wrapping
+// ErrInvalidAddress when the address is not base58check at all.
+func ValidateBase58Check(address string) error {
+	if address == "" {
+		return ErrEmptyAddress
+	}
+
+	_, _, err := base58.CheckDecode(address)
+	switch {
+	case errors.Is(err, base58.ErrChecksum):
+		return fmt.Errorf("%w: %s", ErrBadChecksum, address)
+	case err != nil:
+		return fmt.Errorf("%w: %s is not a base58check address: %v", ErrInvalidAddress, address, err)
+	}
+	return nil
+}
+
+// AddressChecksum returns the base58check checksum of payload, the first four
+// bytes of its double SHA-256. For an address the payload is the version byte
+// followed by the hash; the checksum is appended to it before base58 encoding.
+func AddressChecksum(payload []byte) [4]byte {
+	var checksum [4]byte
+	copy(checksum[:], chainhash.DoubleHashB(payload))
+	return checksum
+}
+
+// findAddressNetwork returns the first known network the address is valid for
+func findAddressNetwork(address string) *chaincfg.Params {
+	for _, network := range knownNetworks {
+		addr, err := btcutil.DecodeAddress(address, network)
+		if err == nil && addr.IsForNet(network) {
+			return network
+		}
+	}
+	return nil
+}
+
+// suggestBase58Correction replaces confusable characters with their likely
+// intended counterparts and returns the first candidate that decodes with a
+// valid checksum.
+func suggestBase58Correction(address string, params *chaincfg.Params) (string, bool) {
+	if isBech32Address(address) {
+		return "", false
+	}
+
+	candidates := []string{""}
+	for _, c := range address {
+		replacements, ok := base58Confusables[c]
+		if !ok {
+			replacements = []rune{c}
+		}
+
+		var next []string
+		for _, prefix := range candidates {
+			for _, r := range replacements {
+				next = append(next, prefix+string(r))
+			}
+		}
+		candidates = next
+
+		// Avoid combinatorial blow-up on heavily mistyped input
+		if len(candidates) > 64 {
+			return "", false
+		}
+	}
+
+	for _, candidate := range candidates {
+		if candidate == address {
+			continue
+		}
+		if addr, err := btcutil.DecodeAddress(candidate, params); err == nil && addr.IsForNet(params) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// isBase58Confusable reports whether c is a character excluded from base58
+func isBase58Confusable(c rune) bool {
+	_, ok := base58Confusables[c]
+	return ok
+}
+
+// isBech32Address reports whether the address carries a known bech32 prefix
+func isBech32Address(address string) bool {
+	lower := strings.ToLower(address)
+	for _, network := range knownNetworks {
+		if strings.HasPrefix(lower, network.Bech32HRPSegwit+"1") {
+			return true
+		}
+	}
+	return false
+}
+
+// addressTypeOf returns the AddressType of a decoded address
+func addressTypeOf(addr btcutil.Address) (AddressType, bool) {
+	switch addr.(type) {
+	case *btcutil.AddressPubKeyHash:
+		return AddressTypeP2PKH, true
+	case *btcutil.AddressScriptHash:
+		return AddressTypeP2SHP2WPKH, true
+	case *btcutil.AddressWitnessPubKeyHash:
+		return AddressTypeP2WPKH, true
+	default:
+		return 0, false
+	}
+}
+
+// checkAddressType returns an error wrapping ErrUnsupportedAddressType when
+// address decodes for params but is not a type BIP-0137 can sign for. Addresses
+// that do not decode are left to the caller to report.
+func checkAddressType(address string, params *chaincfg.Params) error {
+	addr, err := btcutil.DecodeAddress(address, params)
+	if err != nil {
+		return nil
+	}
+	if _, ok := addressTypeOf(addr); !ok {
+		return fmt.Errorf("%w: %s", ErrUnsupportedAddressType, unsupportedAddressTypeName(addr))
+	}
+	return nil
+}
+
+// checkSegwitCompression returns an error wrapping ErrUncompressedSegwit when
+// the signature's header byte claims an uncompressed key and address is a
+// segwit address. Inputs that do not decode are left to the caller to report.
+func checkSegwitCompression(address, signatureBase64 string, params *chaincfg.Params) error {
+	sigBytes, err := DecodeSignature(signatureBase64)
+	if err != nil || sigBytes[0] < MinHeaderByte || sigBytes[0] > 30 {
+		return nil
+	}
+	addr, err := btcutil.DecodeAddress(address, params)
+	if err != nil {
+		return nil
+	}
+	if addrType, ok := addressTypeOf(addr); ok && addrType != AddressTypeP2PKH {
+		return fmt.Errorf("%w: header byte 0x%02x with a %s address", ErrUncompressedSegwit, sigBytes[0], addrType.String())
+	}
+	return nil
+}
+
+// unsupportedAddressTypeName names the type of an address outside BIP-0137
+func unsupportedAddressTypeName(addr btcutil.Address) string {
+	switch addr.(type) {
+	case *btcutil.AddressTaproot:
+		return "P2TR"
+	case *btcutil.AddressWitnessScriptHash:
+		return "P2WSH"
+	case *btcutil.AddressPubKey:
+		return "P2PK"
+	default:
+		return fmt.Sprintf("%T", addr)
+	}
+}
+
+// addressTypeForHeader returns the address type a BIP-0137 header byte selects
+func addressTypeForHeader(header byte) (AddressType, bool) {
+	switch {
+	case header >= 27 && header <= 34:
+		return AddressTypeP2PKH, true
+	case header >= 35 && header <= 38:
+		return AddressTypeP2SHP2WPKH, true
+	case header >= 39 && header <= 42:
+		return AddressTypeP2WPKH, true
+	default:
+		return 0, false
+	}
+}
+
+// AddressForHeader returns the address a signature with the given BIP-0137
+// header byte commits to for a recovered public key, as returned by
+// RecoverPubKey: P2PKH for 27-34, P2SH-P2WPKH for 35-38 and P2WPKH for 39-42.
+func AddressForHeader(pubKey *btcec.PublicKey, compressed bool, header byte, params *chaincfg.Params) (string, error) {
+	addrType, ok := addressTypeForHeader(header)
+	if !ok {
+		return "", fmt.Errorf("%w: %d", ErrBadHeader, header)
+	}
+	return deriveAddress(pubKey, compressed, addrType, params)
+}
+
+// deriveAddress derives the address of the given type for a public key. The
+// compressed flag selects the key serialization that is hashed; segwit address
+// types are only defined for compressed keys and return ErrUncompressedSegwit
+// otherwise.
+func deriveAddress(pubKey *btcec.PublicKey, compressed bool, addrType AddressType, params *chaincfg.Params) (string, error) {
+	if !compressed && addrType != AddressTypeP2PKH {
+		return "", fmt.Errorf("%w: %s", ErrUncompressedSegwit, addrType.String())
+	}
+
+	var serialized []byte
+	if compressed {
+		serialized = pubKey.SerializeCompressed()
+	} else {
+		serialized = pubKey.SerializeUncompressed()
+	}
+	pubKeyHash := btcutil.Hash160(serialized)
+
+	var addr btcutil.Address
+	var err error
+	switch addrType {
+	case AddressTypeP2PKH:
+		addr, err = btcutil.NewAddressPubKeyHash(pubKeyHash, params)
+
+	case AddressTypeP2SHP2WPKH:
+		// The redeem script is the P2WPKH witness program: OP_0 <20-byte hash>
+		redeemScript, scriptErr := txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(pubKeyHash).Script()
+		if scriptErr != nil {
+			return "", scriptErr
+		}
+		addr, err = btcutil.NewAddressScriptHash(redeemScript, params)
+
+	case AddressTypeP2WPKH:
+		addr, err = btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, params)
+
+	default:
+		return "", fmt.Errorf("unsupported address type %d", addrType)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return addr.EncodeAddress(), nil
+}
@@ -0,0 +1,206 @@
+package verify
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestValidateAddress(t *testing.T) {
+	tests := []struct {
+		name           string
+		address        string
+		params         *chaincfg.Params
+		wantErr        bool
+		wantSuggestion string
+	}{
+		{
+			name:    "Valid mainnet P2PKH",
+			address: "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG",
+			params:  &chaincfg.MainNetParams,
+			wantErr: false,
+		},
+		{
+			name:    "Valid testnet P2WPKH",
+			address: "tb1qny80vrtrkk6evjsuy2pqvxh52y37j07t27zcrt",
+			params:  &chaincfg.TestNet3Params,
+			wantErr: false,
+		},
+		{
+			name:           "Zero instead of lowercase o",
+			address:        "1ExJJsNLQDNVVM1s1sdyt105P3GC5r32UG",
+			params:         &chaincfg.MainNetParams,
+			wantErr:        true,
+			wantSuggestion: "did you mean 1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG?",
+		},
+		{
+			name:           "Testnet address with mainnet params",
+			address:        "muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz",
+			params:         &chaincfg.MainNetParams,
+			wantErr:        true,
+			wantSuggestion: "is for network testnet3, not mainnet",
+		},
+		{
+			name:           "Mainnet bech32 address with testnet params",
+			address:        "bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc",
+			params:         &chaincfg.TestNet3Params,
+			wantErr:        true,
+			wantSuggestion: "is for network mainnet, not testnet3",
+		},
+		{
+			name:    "Garbage",
+			address: "not-an-address",
+			params:  &chaincfg.MainNetParams,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAddress(tt.address, tt.params)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAddress() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil {
+				return
+			}
+			if !errors.Is(err, ErrInvalidAddress) {
+				t.Errorf("ValidateAddress() error = %v, want ErrInvalidAddress", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantSuggestion) {
+				t.Errorf("ValidateAddress() error = %q, want it to contain %q", err, tt.wantSuggestion)
+			}
+		})
+	}
+}
+
+func TestSupportedAddressTypes(t *testing.T) {
+	want := []struct {
+		addrType AddressType
+		name     string
+	}{
+		{AddressTypeP2PKH, "P2PKH"},
+		{AddressTypeP2SHP2WPKH, "P2SH-P2WPKH"},
+		{AddressTypeP2WPKH, "P2WPKH"},
+	}
+
+	got := SupportedAddressTypes()
+	if len(got) != len(want) {
+		t.Fatalf("SupportedAddressTypes() = %v, want %d types", got, len(want))
+	}
+	for i, w := range want {
+		if got[i] != w.addrType {
+			t.Errorf("SupportedAddressTypes()[%d] = %d, want %d", i, got[i], w.addrType)
+		}
+		if name := got[i].String(); name != w.name {
+			t.Errorf("AddressType(%d).String() = %q, want %q", got[i], name, w.name)
+		}
+	}
+
+	if name := AddressType(99).String(); name != "AddressType(99)" {
+		t.Errorf("AddressType(99).String() = %q, want %q", name, "AddressType(99)")
+	}
+}
+
+func TestValidateBase58Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		wantErr error
+	}{
+		{"Valid P2PKH", "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG", nil},
+		{"Valid testnet P2PKH", "muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz", nil},
+		{"Flipped character", "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UH", ErrBadChecksum},
+		{"Swapped characters", "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r23UG", ErrBadChecksum},
+		{"Not base58", "bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc", ErrInvalidAddress},
+		{"Empty", "", ErrEmptyAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBase58Check(tt.address)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("ValidateBase58Check() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddressChecksum(t *testing.T) {
+	for _, address := range []string{
+		"1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG",
+		"muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz",
+		"3291hXxutb58vbDVVumaJpopanmfxjVpgJ",
+	} {
+		t.Run(address, func(t *testing.T) {
+			decoded := base58.Decode(address)
+			payload, embedded := decoded[:len(decoded)-4], decoded[len(decoded)-4:]
+
+			if got := AddressChecksum(payload); !bytes.Equal(got[:], embedded) {
+				t.Errorf("AddressChecksum() = %x, want %x", got, embedded)
+			}
+		})
+	}
+}
+
+func TestUncompressedSegwit(t *testing.T) {
+	const message = "Hello, Bitcoin testing!"
+	privKey := testPrivKey(t)
+
+	// Signed with an uncompressed P2PKH header byte (27-30)
+	sig, err := SignBip137Message(privKey, message, false)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+	address, err := deriveAddress(privKey.PubKey(), true, AddressTypeP2WPKH, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("deriveAddress() error = %v", err)
+	}
+
+	if _, err := VerifyBip137SignatureWithOptions(address, message, sig, &chaincfg.MainNetParams); !errors.Is(err, ErrUncompressedSegwit) {
+		t.Errorf("VerifyBip137SignatureWithOptions() error = %v, want ErrUncompressedSegwit", err)
+	}
+	if _, err := VerifyBip137Signature(address, message, sig); !errors.Is(err, ErrUncompressedSegwit) {
+		t.Errorf("VerifyBip137Signature() error = %v, want ErrUncompressedSegwit", err)
+	}
+	if _, err := deriveAddress(privKey.PubKey(), false, AddressTypeP2WPKH, &chaincfg.MainNetParams); !errors.Is(err, ErrUncompressedSegwit) {
+		t.Errorf("deriveAddress() error = %v, want ErrUncompressedSegwit", err)
+	}
+}
+
+func TestAddressForHeader(t *testing.T) {
+	pubKey := testPrivKey(t).PubKey()
+
+	tests := []struct {
+		name       string
+		compressed bool
+		header     byte
+		want       string
+		wantErr    error
+	}{
+		{"Compressed P2PKH", true, 31, testAddress, nil},
+		{"P2SH-P2WPKH", true, 35, "3291hXxutb58vbDVVumaJpopanmfxjVpgJ", nil},
+		{"P2WPKH", true, 42, "bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc", nil},
+		{"Uncompressed P2WPKH", false, 39, "", ErrUncompressedSegwit},
+		{"Undefined header", true, 43, "", ErrBadHeader},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AddressForHeader(pubKey, tt.compressed, tt.header, &chaincfg.MainNetParams)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AddressForHeader() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("AddressForHeader() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
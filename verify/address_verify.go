@@ -0,0 +1,318 @@
+package verify
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// ErrCompressionMismatch is returned under StrictCompression when a signature's
+// header byte claims one key encoding but the address was derived from the
+// other, a sign of a signer that sets the compression flag incorrectly
+var ErrCompressionMismatch = errors.New("header byte compression flag contradicts the address")
+
+// ErrAddressNotAllowed is returned when the Allowlist or Blocklist option
+// rejects the address, whether or not its signature is valid
+var ErrAddressNotAllowed = errors.New("address not allowed")
+
+// ErrInvalidPrehash is returned under Prehashed when the message is not a
+// hex-encoded SHA-256 digest
+var ErrInvalidPrehash = errors.New("prehashed message is not a hex-encoded 32-byte digest")
+
+// VerifyBip137SignatureWithOptions verifies a BIP-0137 signature against an address
+// using the package's own recovery code, so that the message formatting can be
+// adjusted through opts (see VerifyOptions).
+//
+// Unlike VerifyBip137SignatureWithParams, the header byte strictly selects the
+// address type as described in BIP-0137: 27-34 for P2PKH, 35-38 for P2SH-P2WPKH
+// and 39-42 for P2WPKH. A signature that recovers to a different address is
+// reported as invalid without an error.
+func VerifyBip137SignatureWithOptions(address, message, signatureBase64 string, params *chaincfg.Params, opts ...Option) (bool, error) {
+	msg := SignedMessage{Address: address, Message: message, Signature: signatureBase64}
+	options := newVerifyOptions(opts...)
+	return audited(msg, params, messageDigest(message, options), func() (bool, error) {
+		return verifyWithOptions(address, message, signatureBase64, params, options)
+	})
+}
+
+// verifyWithOptions implements VerifyBip137SignatureWithOptions for resolved options
+func verifyWithOptions(address, message, signatureBase64 string, params *chaincfg.Params, options VerifyOptions) (bool, error) {
+	// Validate inputs
+	if address == "" {
+		return false, ErrEmptyAddress
+	}
+	if message == "" {
+		return false, ErrEmptyMessage
+	}
+
+	messageHash, err := hashMessage(message, options)
+	if err != nil {
+		return false, err
+	}
+
+	return verifyDigest(address, messageHash, signatureBase64, params, options)
+}
+
+// VerifyPrefixed verifies a BIP-0137 signature over a message that the caller
+// has already serialized with its prefix, i.e. the exact bytes whose double
+// SHA-256 was signed. No formatting is applied; otherwise it behaves like
+// VerifyBip137SignatureWithOptions with default options.
+func VerifyPrefixed(address string, prefixedMessage []byte, signatureBase64 string, params *chaincfg.Params) (bool, error) {
+	if len(prefixedMessage) == 0 {
+		return false, ErrEmptyMessage
+	}
+
+	messageHash := doubleSHA256Hash(prefixedMessage)
+	msg := SignedMessage{Address: address, Signature: signatureBase64}
+	return audited(msg, params, digestOf(messageHash), func() (bool, error) {
+		return verifyDigest(address, messageHash, signatureBase64, params, DefaultVerifyOptions())
+	})
+}
+
+// VerifyCommitment verifies a BIP-0137 signature against a message digest, as
+// returned by MessageHash, without the message itself. It otherwise behaves
+// like VerifyBip137SignatureWithOptions with default options.
+//
+// A valid result only shows that the address signed the digest. The caller
+// must trust whoever supplied the digest to have computed it from the message
+// they care about; nothing here ties the digest to any message content.
+func VerifyCommitment(address string, messageHash [32]byte, signatureBase64 string, params *chaincfg.Params) (bool, error) {
+	msg := SignedMessage{Address: address, Signature: signatureBase64}
+	return audited(msg, params, digestOf(messageHash[:]), func() (bool, error) {
+		return verifyDigest(address, messageHash[:], signatureBase64, params, DefaultVerifyOptions())
+	})
+}
+
+// VerifyAndRecover verifies a BIP-0137 signature like
+// VerifyBip137SignatureWithOptions with default options and also returns the
+// public key recovered from it, so callers that need both do not recover the
+// key twice. The key is returned even when it does not match the address, and
+// is nil if none could be recovered.
+func VerifyAndRecover(address, message, signatureBase64 string, params *chaincfg.Params) (valid bool, pubKey *btcec.PublicKey, err error) {
+	if message == "" {
+		return false, nil, ErrEmptyMessage
+	}
+
+	options := DefaultVerifyOptions()
+	msg := SignedMessage{Address: address, Message: message, Signature: signatureBase64}
+	valid, err = audited(msg, params, messageDigest(message, options), func() (bool, error) {
+		var verifyErr error
+		valid, pubKey, verifyErr = verifyDigestKey(address, hashBitcoinMessage(message, options), signatureBase64, params, options)
+		return valid, verifyErr
+	})
+	return valid, pubKey, err
+}
+
+// verifyDigest verifies a signature over a message digest against an address
+func verifyDigest(address string, messageHash []byte, signatureBase64 string, params *chaincfg.Params, options VerifyOptions) (bool, error) {
+	valid, _, err := verifyDigestKey(address, messageHash, signatureBase64, params, options)
+	return valid, err
+}
+
+// verifyDigestKey implements verifyDigest and also returns the recovered key,
+// which is nil if no key could be recovered
+func verifyDigestKey(address string, messageHash []byte, signatureBase64 string, params *chaincfg.Params, options VerifyOptions) (bool, *btcec.PublicKey, error) {
+	if address == "" {
+		return false, nil, ErrEmptyAddress
+	}
+	if signatureBase64 == "" {
+		return false, nil, ErrEmptySignature
+	}
+
+	addr, err := decodeAddress(address, params)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return verifyDecoded(addr, messageHash, signatureBase64, params, options)
+}
+
+// VerifyBip137SignatureRaw verifies a BIP-0137 signature given as its 65 raw
+// bytes, for callers that never had it in base64. It otherwise behaves like
+// VerifyBip137SignatureWithOptions with default options.
+func VerifyBip137SignatureRaw(address, message string, sig []byte, params *chaincfg.Params) (bool, error) {
+	msg := SignedMessage{Address: address, Message: message, Signature: base64.StdEncoding.EncodeToString(sig)}
+	return audited(msg, params, messageDigest(message, DefaultVerifyOptions()), func() (bool, error) {
+		return verifyBip137SignatureRaw(address, message, sig, params)
+	})
+}
+
+// verifyBip137SignatureRaw implements VerifyBip137SignatureRaw
+func verifyBip137SignatureRaw(address, message string, sig []byte, params *chaincfg.Params) (bool, error) {
+	if address == "" {
+		return false, ErrEmptyAddress
+	}
+	if message == "" {
+		return false, ErrEmptyMessage
+	}
+	if len(sig) == 0 {
+		return false, ErrEmptySignature
+	}
+	if len(sig) != CompactSignatureLength {
+		return false, fmt.Errorf("%w: %d (expected %d bytes)", ErrBadLength, len(sig), CompactSignatureLength)
+	}
+	if err := validateHeaderByte(sig[0]); err != nil {
+		return false, err
+	}
+
+	addr, err := decodeAddress(address, params)
+	if err != nil {
+		return false, err
+	}
+
+	options := DefaultVerifyOptions()
+	valid, _, err := verifyCompact(addr, hashBitcoinMessage(message, options), bytes.Clone(sig), params, options)
+	return valid, err
+}
+
+// decodeAddress decodes an address for the requested network, reporting
+// failures as ValidateAddress does
+func decodeAddress(address string, params *chaincfg.Params) (btcutil.Address, error) {
+	if err := ValidateAddress(address, params); err != nil {
+		return nil, err
+	}
+	addr, err := btcutil.DecodeAddress(address, params)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode address: %w", err)
+	}
+	return addr, nil
+}
+
+// verifyDecoded implements verifyDigestKey for an already decoded address
+func verifyDecoded(addr btcutil.Address, messageHash []byte, signatureBase64 string, params *chaincfg.Params, options VerifyOptions) (bool, *btcec.PublicKey, error) {
+	sigBytes, err := decodeSignature(signatureBase64, options.LenientLength)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return verifyCompact(addr, messageHash, sigBytes, params, options)
+}
+
+// verifyCompact implements verifyDecoded for a decoded signature, which it may
+// modify
+func verifyCompact(addr btcutil.Address, messageHash, sigBytes []byte, params *chaincfg.Params, options VerifyOptions) (bool, *btcec.PublicKey, error) {
+	if !options.addressAllowed(addr.EncodeAddress(), params) {
+		return false, nil, fmt.Errorf("%w: %s", ErrAddressNotAllowed, addr.EncodeAddress())
+	}
+
+	// Map a raw recovery ID header to the header of the address's type
+	if options.LenientHeader && sigBytes[0] <= 3 {
+		if addrType, ok := addressTypeOf(addr); ok {
+			header, err := headerByteFor(int(sigBytes[0]), true, addrType)
+			if err != nil {
+				return false, nil, err
+			}
+			LogDebug("Treating header byte %d as raw recovery ID, using %d", sigBytes[0], header)
+			sigBytes[0] = header
+		}
+	}
+
+	// Recover the signing key
+	pubKey, compressed, err := recoverPublicKey(sigBytes, messageHash)
+	if err != nil {
+		return false, nil, err
+	}
+
+	derived, err := deriveAddressForHeader(pubKey, compressed, sigBytes[0], addr, params, options)
+	if err != nil {
+		return false, pubKey, err
+	}
+
+	LogDebug("Derived address %s, expected %s", derived, addr.EncodeAddress())
+	if derived == addr.EncodeAddress() {
+		return true, pubKey, nil
+	}
+
+	// The key may match the address under the other encoding
+	if addrType, ok := addressTypeOf(addr); ok {
+		if other, err := deriveAddress(pubKey, !compressed, addrType, params); err == nil && other == addr.EncodeAddress() {
+			LogWarning("Header byte 0x%02x claims compressed=%t, but %s uses the other key encoding", sigBytes[0], compressed, other)
+			if options.StrictCompression {
+				return false, pubKey, fmt.Errorf("%w: header byte 0x%02x", ErrCompressionMismatch, sigBytes[0])
+			}
+		}
+	}
+
+	return false, pubKey, nil
+}
+
+// deriveAddressForHeader derives the address of the same type as addr from the
+// recovered key, provided the header byte permits that address type.
+func deriveAddressForHeader(pubKey *btcec.PublicKey, compressed bool, headerByte byte, addr btcutil.Address, params *chaincfg.Params, opts VerifyOptions) (string, error) {
+	addrType, ok := addressTypeOf(addr)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedAddressType, unsupportedAddressTypeName(addr))
+	}
+
+	// Segwit addresses only commit to compressed keys
+	if !compressed && addrType != AddressTypeP2PKH {
+		return "", fmt.Errorf("%w: header byte 0x%02x with a %s address", ErrUncompressedSegwit, headerByte, addrType.String())
+	}
+
+	if !headerAllowsAddressType(headerByte, addrType, opts) {
+		return "", fmt.Errorf("%w: 0x%02x cannot be used with a %s address", ErrBadHeader, headerByte, addrType.String())
+	}
+
+	return deriveAddress(pubKey, compressed, addrType, params)
+}
+
+// CouldMatch reports whether a signature's header byte is compatible with the
+// type of address, as a cheap filter before full verification; it does not
+// recover the key. The address may be for any known network. Compressed P2PKH
+// header bytes (31-34) are taken to be compatible with segwit addresses too,
+// as Electrum and Ledger sign with them, so a true result only means that
+// verification is worth attempting.
+func CouldMatch(address, signatureBase64 string) (bool, error) {
+	if address == "" {
+		return false, ErrEmptyAddress
+	}
+
+	network := findAddressNetwork(address)
+	if network == nil {
+		return false, ValidateAddress(address, &chaincfg.MainNetParams)
+	}
+	addr, err := btcutil.DecodeAddress(address, network)
+	if err != nil {
+		return false, fmt.Errorf("could not decode address: %w", err)
+	}
+	addrType, ok := addressTypeOf(addr)
+	if !ok {
+		return false, fmt.Errorf("%w: %s", ErrUnsupportedAddressType, unsupportedAddressTypeName(addr))
+	}
+
+	if signatureBase64 == "" {
+		return false, ErrEmptySignature
+	}
+	sigBytes, err := DecodeSignature(signatureBase64)
+	if err != nil {
+		return false, err
+	}
+	if err := validateHeaderByte(sigBytes[0]); err != nil {
+		return false, err
+	}
+
+	return headerAllowsAddressType(sigBytes[0], addrType, VerifyOptions{LedgerCompat: true}), nil
+}
+
+// headerAllowsAddressType reports whether a BIP-0137 header byte may be used to
+// sign for the given address type.
+func headerAllowsAddressType(headerByte byte, addrType AddressType, opts VerifyOptions) bool {
+	// Ledger signs segwit addresses with compressed P2PKH header bytes
+	ledgerHeader := opts.LedgerCompat && headerByte >= 31 && headerByte <= 34
+
+	switch addrType {
+	case AddressTypeP2PKH:
+		return headerByte >= 27 && headerByte <= 34
+	case AddressTypeP2SHP2WPKH:
+		return (headerByte >= 35 && headerByte <= 38) || ledgerHeader
+	case AddressTypeP2WPKH:
+		return (headerByte >= 39 && headerByte <= 42) || ledgerHeader
+	default:
+		return false
+	}
+}
@@ -0,0 +1,193 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// addressKey identifies an address by its type and 20-byte hash
+type addressKey struct {
+	addrType AddressType
+	hash     [20]byte
+}
+
+// AddressSet is a set of pre-decoded addresses that can be checked against a
+// public key without encoding any address
+type AddressSet struct {
+	addresses map[addressKey]string
+	params    *chaincfg.Params
+}
+
+// BuildAddressSet decodes addresses for the network described by params into
+// an AddressSet. Every address must be of a type BIP-0137 supports.
+func BuildAddressSet(addresses []string, params *chaincfg.Params) (*AddressSet, error) {
+	set := &AddressSet{addresses: make(map[addressKey]string, len(addresses)), params: params}
+
+	for _, address := range addresses {
+		if err := ValidateAddress(address, params); err != nil {
+			return nil, err
+		}
+		addr, err := btcutil.DecodeAddress(address, params)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode address: %w", err)
+		}
+
+		addrType, ok := addressTypeOf(addr)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedAddressType, unsupportedAddressTypeName(addr))
+		}
+
+		key := addressKey{addrType: addrType}
+		copy(key.hash[:], addr.ScriptAddress())
+		set.addresses[key] = addr.EncodeAddress()
+	}
+
+	return set, nil
+}
+
+// Len returns the number of distinct addresses in the set
+func (s *AddressSet) Len() int {
+	return len(s.addresses)
+}
+
+// Contains reports whether any address of pubKey is in the set and returns
+// it. Both serializations of the key are checked for P2PKH; segwit addresses
+// only exist for the compressed key.
+func (s *AddressSet) Contains(pubKey *btcec.PublicKey) (string, bool) {
+	for _, candidate := range addressCandidates {
+		if address, ok := s.lookup(pubKey, candidate); ok {
+			return address, true
+		}
+	}
+
+	return "", false
+}
+
+// Verify recovers the signer of message and returns the addresses in the set
+// that belong to it, or none if the signer has no address in the set. As in
+// VerifyBip137SignatureWithOptions, only addresses of the types the header
+// byte permits and of the key serialization it selects are considered, so
+// segwit members match compressed P2PKH header bytes only with LedgerCompat;
+// a raw recovery ID accepted with LenientHeader permits every type. The type
+// the header byte selects (or the one given with WithPreferType) is tried
+// first, as the most likely match. With the default StopOnFirstMatch at most
+// one address is returned. The message is formatted and the signature decoded
+// according to opts, and addresses rejected by the Allowlist or Blocklist
+// option are left out. As a search for the
+// signer it is not recorded in the audit log; see SetAuditLog.
+func (s *AddressSet) Verify(message, signatureBase64 string, opts ...Option) ([]string, error) {
+	if message == "" {
+		return nil, ErrEmptyMessage
+	}
+	if signatureBase64 == "" {
+		return nil, ErrEmptySignature
+	}
+
+	options := newVerifyOptions(opts...)
+	messageHash, err := hashMessage(message, options)
+	if err != nil {
+		return nil, err
+	}
+	sigBytes, err := decodeSignature(signatureBase64, options.LenientLength)
+	if err != nil {
+		return nil, err
+	}
+
+	// A raw recovery ID names no address type, so recover as compressed
+	// P2PKH and allow every type, as verifyCompact does for each address
+	rawRecoveryID := options.LenientHeader && sigBytes[0] <= 3
+	if rawRecoveryID {
+		header, err := headerByteFor(int(sigBytes[0]), true, AddressTypeP2PKH)
+		if err != nil {
+			return nil, err
+		}
+		LogDebug("Treating header byte %d as raw recovery ID, using %d", sigBytes[0], header)
+		sigBytes[0] = header
+	}
+
+	pubKey, compressed, err := recoverPublicKey(sigBytes, messageHash)
+	if err != nil {
+		return nil, err
+	}
+
+	preferred, _ := addressTypeForHeader(sigBytes[0])
+	if options.preferTypeSet {
+		preferred = options.PreferType
+	}
+
+	var matches []string
+	for _, candidate := range orderCandidates(preferred, compressed) {
+		if candidate.compressed != compressed {
+			continue
+		}
+		if !rawRecoveryID && !headerAllowsAddressType(sigBytes[0], candidate.addrType, options) {
+			continue
+		}
+		if address, ok := s.lookup(pubKey, candidate); ok && options.addressAllowed(address, s.params) {
+			matches = append(matches, address)
+			if options.StopOnFirstMatch {
+				break
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// addressCandidate is an address type and key serialization a signer's
+// address may have been derived from
+type addressCandidate struct {
+	addrType   AddressType
+	compressed bool
+}
+
+// addressCandidates lists every candidate; segwit addresses only exist for the
+// compressed key
+var addressCandidates = []addressCandidate{
+	{AddressTypeP2PKH, true},
+	{AddressTypeP2PKH, false},
+	{AddressTypeP2WPKH, true},
+	{AddressTypeP2SHP2WPKH, true},
+}
+
+// orderCandidates returns addressCandidates with those of the preferred type
+// first, starting with the key serialization selected by compressed
+func orderCandidates(preferred AddressType, compressed bool) []addressCandidate {
+	ordered := make([]addressCandidate, 0, len(addressCandidates))
+	for _, first := range []bool{true, false} {
+		for _, candidate := range addressCandidates {
+			isFirst := candidate.addrType == preferred && candidate.compressed == compressed
+			if isFirst == first {
+				ordered = append(ordered, candidate)
+			}
+		}
+	}
+	return ordered
+}
+
+// candidateHash returns the 20-byte hash committed to by the candidate's
+// address for pubKey. It is a variable so tests can observe the order in which
+// candidates are tried.
+var candidateHash = func(pubKey *btcec.PublicKey, candidate addressCandidate) []byte {
+	if !candidate.compressed {
+		return btcutil.Hash160(pubKey.SerializeUncompressed())
+	}
+
+	hash := btcutil.Hash160(pubKey.SerializeCompressed())
+	if candidate.addrType == AddressTypeP2SHP2WPKH {
+		// The P2SH-P2WPKH script hash commits to the witness program OP_0 <hash>
+		return btcutil.Hash160(append([]byte{0x00, 0x14}, hash...))
+	}
+	return hash
+}
+
+// lookup returns the address in the set for pubKey under candidate
+func (s *AddressSet) lookup(pubKey *btcec.PublicKey, candidate addressCandidate) (string, bool) {
+	key := addressKey{addrType: candidate.addrType}
+	copy(key.hash[:], candidateHash(pubKey, candidate))
+	address, ok := s.addresses[key]
+	return address, ok
+}
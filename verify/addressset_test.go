@@ -0,0 +1,200 @@
+package verify
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestAddressSet(t *testing.T) {
+	pubKey := testPrivKey(t).PubKey()
+
+	tests := []struct {
+		name      string
+		addresses []string
+		want      string
+		wantOK    bool
+	}{
+		{"P2PKH", []string{"194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", testAddress}, testAddress, true},
+		{"Uncompressed P2PKH", []string{"1ELReFsTCUY2mfaDTy32qxYiT49z786eFg"}, "1ELReFsTCUY2mfaDTy32qxYiT49z786eFg", true},
+		{"P2SH-P2WPKH", []string{"3291hXxutb58vbDVVumaJpopanmfxjVpgJ"}, "3291hXxutb58vbDVVumaJpopanmfxjVpgJ", true},
+		{"Uppercase P2WPKH", []string{"BC1QNY80VRTRKK6EVJSUY2PQVXH52Y37J07TQCETCC"}, "bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc", true},
+		{"Other key", []string{"194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "bc1qtpl26utzhqurdeqhxe7s269hqzte504kqxavae"}, "", false},
+		{"Empty set", nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := BuildAddressSet(tt.addresses, &chaincfg.MainNetParams)
+			if err != nil {
+				t.Fatalf("BuildAddressSet() error = %v", err)
+			}
+
+			got, ok := set.Contains(pubKey)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Contains() = %q, %v; want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	_, err := BuildAddressSet([]string{"bc1ppv609nr0vr25u07u95waq5lucwfm6tde4nydujnu8npg4q75mr5sxq8lt3"}, &chaincfg.MainNetParams)
+	if !errors.Is(err, ErrUnsupportedAddressType) {
+		t.Errorf("BuildAddressSet() error = %v, want ErrUnsupportedAddressType", err)
+	}
+	_, err = BuildAddressSet([]string{"muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz"}, &chaincfg.MainNetParams)
+	if !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("BuildAddressSet() error = %v, want ErrInvalidAddress", err)
+	}
+}
+
+func BenchmarkAddressSetContains(b *testing.B) {
+	// A large set of unrelated addresses and one belonging to the key
+	var addresses []string
+	for i := 0; i < 10000; i++ {
+		var seed [32]byte
+		copy(seed[:], fmt.Sprintf("address set benchmark key %d", i))
+		privKey, _ := btcec.PrivKeyFromBytes(seed[:])
+		address, err := deriveAddress(privKey.PubKey(), true, SupportedAddressTypes()[i%3], &chaincfg.MainNetParams)
+		if err != nil {
+			b.Fatalf("deriveAddress() error = %v", err)
+		}
+		addresses = append(addresses, address)
+	}
+	addresses = append(addresses, testAddress)
+
+	set, err := BuildAddressSet(addresses, &chaincfg.MainNetParams)
+	if err != nil {
+		b.Fatalf("BuildAddressSet() error = %v", err)
+	}
+	pubKey := testPrivKey(b).PubKey()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, ok := set.Contains(pubKey); !ok {
+			b.Fatal("Contains() = false")
+		}
+	}
+}
+
+func TestAddressSetVerify(t *testing.T) {
+	const message = "Which of my addresses signed this?"
+	signature, err := SignBip137Message(testPrivKey(t), message, true)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+
+	set, err := BuildAddressSet([]string{
+		testAddress,
+		"3291hXxutb58vbDVVumaJpopanmfxjVpgJ",
+		"bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc",
+	}, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("BuildAddressSet() error = %v", err)
+	}
+
+	// Record the order in which candidate address types are derived
+	var tried []AddressType
+	hash := candidateHash
+	candidateHash = func(pubKey *btcec.PublicKey, candidate addressCandidate) []byte {
+		tried = append(tried, candidate.addrType)
+		return hash(pubKey, candidate)
+	}
+	t.Cleanup(func() { candidateHash = hash })
+
+	tests := []struct {
+		name      string
+		opts      []Option
+		want      []string
+		wantFirst AddressType
+	}{
+		{"Header byte type first", nil, []string{testAddress}, AddressTypeP2PKH},
+		{"Preferred type first", []Option{WithLedgerCompat(), WithPreferType(AddressTypeP2WPKH)}, []string{"bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc"}, AddressTypeP2WPKH},
+		{"Header byte restricts types", []Option{WithStopOnFirstMatch(false)}, []string{testAddress}, AddressTypeP2PKH},
+		{
+			"All matches",
+			[]Option{WithLedgerCompat(), WithStopOnFirstMatch(false)},
+			[]string{testAddress, "bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc", "3291hXxutb58vbDVVumaJpopanmfxjVpgJ"},
+			AddressTypeP2PKH,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tried = nil
+			got, err := set.Verify(message, signature, tt.opts...)
+			if err != nil {
+				t.Fatalf("AddressSet.Verify() error = %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("AddressSet.Verify() = %v, want %v", got, tt.want)
+			}
+			if len(tried) == 0 || tried[0] != tt.wantFirst {
+				t.Errorf("AddressSet.Verify() tried %v, want %v first", tried, tt.wantFirst)
+			}
+			if len(tt.opts) == 0 && len(tried) != 1 {
+				t.Errorf("AddressSet.Verify() tried %v, want to stop after the first match", tried)
+			}
+		})
+	}
+}
+
+func TestAddressSetVerifyHeader(t *testing.T) {
+	const message = "Which of my addresses signed this?"
+	set, err := BuildAddressSet([]string{
+		testAddress,
+		"3291hXxutb58vbDVVumaJpopanmfxjVpgJ",
+		"bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc",
+	}, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("BuildAddressSet() error = %v", err)
+	}
+
+	_, segwit, err := SignForAddressType(testPrivKey(t), message, AddressTypeP2WPKH, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("SignForAddressType() error = %v", err)
+	}
+	compressed, err := SignBip137Message(testPrivKey(t), message, true)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+	raw, _ := base64.StdEncoding.DecodeString(compressed)
+	rawHeader := append([]byte{raw[0] - 31}, raw[1:]...)
+	trailing := append(bytes.Clone(raw), 0x00, 0x00)
+
+	tests := []struct {
+		name      string
+		signature string
+		opts      []Option
+		want      []string
+		wantErr   bool
+	}{
+		{"P2WPKH header", segwit, nil, []string{"bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc"}, false},
+		{"Raw recovery ID", base64.StdEncoding.EncodeToString(rawHeader), nil, nil, true},
+		{
+			"Raw recovery ID with LenientHeader",
+			base64.StdEncoding.EncodeToString(rawHeader),
+			[]Option{WithLenientHeader()},
+			[]string{testAddress, "bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc", "3291hXxutb58vbDVVumaJpopanmfxjVpgJ"},
+			false,
+		},
+		{"Trailing bytes", base64.StdEncoding.EncodeToString(trailing), nil, nil, true},
+		{"Trailing bytes with LenientLength", base64.StdEncoding.EncodeToString(trailing), []Option{WithLenientLength()}, []string{testAddress}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := set.Verify(message, tt.signature, append(tt.opts, WithStopOnFirstMatch(false))...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddressSet.Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("AddressSet.Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
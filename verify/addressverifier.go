@@ -0,0 +1,62 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// AddressVerifier verifies many signatures claimed to be made for the same
+// address. The address is decoded once, when the verifier is created, instead
+// of on every call. It is safe for concurrent use.
+type AddressVerifier struct {
+	addr    btcutil.Address
+	params  *chaincfg.Params
+	options VerifyOptions
+}
+
+// NewAddressVerifier decodes address for the network given by params and
+// returns a verifier for it. opts are applied as for
+// VerifyBip137SignatureWithOptions.
+func NewAddressVerifier(address string, params *chaincfg.Params, opts ...Option) (*AddressVerifier, error) {
+	if address == "" {
+		return nil, ErrEmptyAddress
+	}
+	if err := ValidateAddress(address, params); err != nil {
+		return nil, err
+	}
+
+	addr, err := btcutil.DecodeAddress(address, params)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode address: %w", err)
+	}
+
+	return &AddressVerifier{
+		addr:    addr,
+		params:  params,
+		options: newVerifyOptions(opts...),
+	}, nil
+}
+
+// Verify verifies a signature over message against the verifier's address. It
+// gives the same result as VerifyBip137SignatureWithOptions.
+func (v *AddressVerifier) Verify(message, signatureBase64 string) (bool, error) {
+	if message == "" {
+		return false, ErrEmptyMessage
+	}
+	if signatureBase64 == "" {
+		return false, ErrEmptySignature
+	}
+
+	msg := SignedMessage{Address: v.addr.EncodeAddress(), Message: message, Signature: signatureBase64}
+	return audited(msg, v.params, messageDigest(message, v.options), func() (bool, error) {
+		messageHash, err := hashMessage(message, v.options)
+		if err != nil {
+			return false, err
+		}
+
+		valid, _, err := verifyDecoded(v.addr, messageHash, signatureBase64, v.params, v.options)
+		return valid, err
+	})
+}
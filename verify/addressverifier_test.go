@@ -0,0 +1,73 @@
+package verify
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestAddressVerifier(t *testing.T) {
+	v, err := NewAddressVerifier("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("NewAddressVerifier() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		message   string
+		signature string
+		wantValid bool
+		wantErr   bool
+	}{
+		{"Valid signature", "Hello, Bitcoin testing!", knownSignature, true, false},
+		{"Different message", "Hello, Bitcoin!", knownSignature, false, false},
+		{"Empty signature", "Hello, Bitcoin testing!", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, err := v.Verify(tt.message, tt.signature)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddressVerifier.Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotValid != tt.wantValid {
+				t.Errorf("AddressVerifier.Verify() = %v, want %v", gotValid, tt.wantValid)
+			}
+
+			// The result matches verifying from scratch
+			want, _ := VerifyBip137SignatureWithOptions("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", tt.message, tt.signature, &chaincfg.MainNetParams)
+			if gotValid != want {
+				t.Errorf("AddressVerifier.Verify() = %v, VerifyBip137SignatureWithOptions() = %v", gotValid, want)
+			}
+		})
+	}
+
+	if _, err := NewAddressVerifier("not-an-address", &chaincfg.MainNetParams); err == nil {
+		t.Errorf("NewAddressVerifier() error = nil, want an error for an invalid address")
+	}
+}
+
+func BenchmarkAddressVerifier(b *testing.B) {
+	const address = "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9"
+	const message = "Hello, Bitcoin testing!"
+
+	b.Run("Repeated decoding", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if valid, err := VerifyBip137SignatureWithOptions(address, message, knownSignature, &chaincfg.MainNetParams); !valid || err != nil {
+				b.Fatalf("VerifyBip137SignatureWithOptions() = %v, %v", valid, err)
+			}
+		}
+	})
+
+	b.Run("AddressVerifier", func(b *testing.B) {
+		v, err := NewAddressVerifier(address, &chaincfg.MainNetParams)
+		if err != nil {
+			b.Fatalf("NewAddressVerifier() error = %v", err)
+		}
+		for i := 0; i < b.N; i++ {
+			if valid, err := v.Verify(message, knownSignature); !valid || err != nil {
+				b.Fatalf("AddressVerifier.Verify() = %v, %v", valid, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package verify
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+var (
+	auditMu  sync.Mutex
+	auditLog io.Writer
+)
+
+// auditRecord is one line of the audit log
+type auditRecord struct {
+	Time      time.Time `json:"time"`
+	Address   string    `json:"address"`
+	Valid     bool      `json:"valid"`
+	Recovered string    `json:"recovered,omitempty"`
+	Duration  string    `json:"duration"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// telemetrySampleRate holds the float64 bits of the fraction of verifications
+// that are audited
+var telemetrySampleRate atomic.Uint64
+
+func init() {
+	telemetrySampleRate.Store(math.Float64bits(1))
+}
+
+// SetTelemetrySampleRate sets the fraction of verifications, from 0 to 1, that
+// are recorded in the audit log. Each verification is sampled independently at
+// random, and unsampled ones skip the extra key recovery an audit record needs.
+// Rates outside the range are clamped. The default of 1 records every
+// verification.
+func SetTelemetrySampleRate(rate float64) {
+	telemetrySampleRate.Store(math.Float64bits(math.Min(math.Max(rate, 0), 1)))
+}
+
+// sampled reports whether the current verification should be recorded
+func sampled() bool {
+	rate := math.Float64frombits(telemetrySampleRate.Load())
+	return rate >= 1 || rand.Float64() < rate
+}
+
+// SetAuditLog sets a writer that receives one JSON line per BIP-0137
+// verification of a signature against an address or public key: through
+// VerifyBip137SignatureWithParams and VerifyBip137SignatureWithOptions (and
+// the functions built on them), VerifyBip137SignatureWithContext,
+// VerifyBip137SignatureRaw, VerifyWithPubKey, VerifyTolerant, VerifyAndRecover,
+// VerifyCommitment, VerifyPrefixed, AddressVerifier.Verify, Client.Verify and
+// VerifyMessageRPC. Each line records the time, the address, the result, the
+// address recovered from the signature for the header byte's address type, and
+// how long verification took. Searches for a signer, such as AddressSet.Verify,
+// VerifyUnderDescriptor and VerifyUnderXpub, and other signature schemes are
+// not recorded. Unlike logging it does not depend on the log level. A nil
+// writer disables it, and SetTelemetrySampleRate limits it to a fraction of
+// verifications.
+func SetAuditLog(w io.Writer) {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	auditLog = w
+}
+
+// audited runs verify and, if an audit log is set, records its outcome. digest
+// returns the digest that was signed, so that the recovered address matches
+// the one verify saw; it is only called after verify, for recorded outcomes.
+func audited(msg SignedMessage, params *chaincfg.Params, digest func() ([]byte, error), verify func() (bool, error)) (bool, error) {
+	auditMu.Lock()
+	enabled := auditLog != nil
+	auditMu.Unlock()
+	if !enabled || !sampled() {
+		return verify()
+	}
+
+	start := time.Now()
+	valid, err := verify()
+	record := auditRecord{
+		Time:      start.UTC(),
+		Address:   msg.Address,
+		Valid:     valid,
+		Recovered: recoveredAddress(msg.Signature, digest, params),
+		Duration:  time.Since(start).String(),
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	line, marshalErr := json.Marshal(record)
+	if marshalErr != nil {
+		LogError("Failed to encode audit record: %v", marshalErr)
+		return valid, err
+	}
+
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	if auditLog != nil {
+		if _, writeErr := auditLog.Write(append(line, '\n')); writeErr != nil {
+			LogError("Failed to write audit record: %v", writeErr)
+		}
+	}
+
+	return valid, err
+}
+
+// messageDigest returns a function computing the digest signed for message
+// under opts, for audited
+func messageDigest(message string, opts VerifyOptions) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		return hashMessage(message, opts)
+	}
+}
+
+// digestOf returns a function returning messageHash, for audited
+func digestOf(messageHash []byte) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		return messageHash, nil
+	}
+}
+
+// recoveredAddress returns the address of the type selected by the header
+// byte for the key recovered from the signature over digest, or "" if there
+// is none
+func recoveredAddress(signatureBase64 string, digest func() ([]byte, error), params *chaincfg.Params) string {
+	sigBytes, err := DecodeSignature(signatureBase64)
+	if err != nil {
+		return ""
+	}
+	addrType, ok := addressTypeForHeader(sigBytes[0])
+	if !ok {
+		return ""
+	}
+
+	messageHash, err := digest()
+	if err != nil {
+		return ""
+	}
+	pubKey, compressed, err := recoverPublicKey(sigBytes, messageHash)
+	if err != nil {
+		return ""
+	}
+
+	address, err := deriveAddress(pubKey, compressed, addrType, params)
+	if err != nil {
+		return ""
+	}
+	return address
+}
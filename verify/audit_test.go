@@ -0,0 +1,166 @@
+package verify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestSetAuditLog(t *testing.T) {
+	var buf bytes.Buffer
+	SetAuditLog(&buf)
+	defer SetAuditLog(nil)
+
+	// Audit records are written even when logging is off
+	level := GetLogLevel()
+	SetLogLevel(LogLevelNone)
+	defer SetLogLevel(level)
+
+	VerifyBip137Signature("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", knownSignature)
+	VerifyBip137SignatureWithOptions(testAddress, "Hello, Bitcoin testing!", knownSignature, &chaincfg.MainNetParams)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("audit log has %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+
+	want := []auditRecord{
+		{Address: "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", Valid: true, Recovered: "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9"},
+		{Address: testAddress, Valid: false, Recovered: "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9"},
+	}
+	for i, line := range lines {
+		var got auditRecord
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("audit line %d is not JSON: %v", i, err)
+		}
+		if got.Address != want[i].Address || got.Valid != want[i].Valid || got.Recovered != want[i].Recovered {
+			t.Errorf("audit line %d = %+v, want %+v", i, got, want[i])
+		}
+		if got.Time.IsZero() || got.Duration == "" {
+			t.Errorf("audit line %d = %+v, want time and duration", i, got)
+		}
+	}
+
+	SetAuditLog(nil)
+	VerifyBip137Signature("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", knownSignature)
+	if got := strings.Count(buf.String(), "\n"); got != 2 {
+		t.Errorf("audit log has %d lines after disabling, want 2", got)
+	}
+}
+
+func TestAuditedEntryPoints(t *testing.T) {
+	var buf bytes.Buffer
+	SetAuditLog(&buf)
+	defer SetAuditLog(nil)
+
+	const (
+		signer  = "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9"
+		message = "Hello, Bitcoin testing!"
+		pubKey  = "034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23c"
+	)
+	raw, _ := base64.StdEncoding.DecodeString(knownSignature)
+
+	// Signed over the message with a trailing newline, as WithTrailingNewline
+	// formats it, so recovering over the bare message gives another address
+	newlineSig, err := SignBip137Message(testPrivKey(t), message+"\n", true)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		verify        func()
+		wantAddress   string
+		wantRecovered string
+	}{
+		{"VerifyBip137SignatureWithContext", func() {
+			VerifyBip137SignatureWithContext(context.Background(), SignedMessage{Address: signer, Message: message, Signature: knownSignature})
+		}, signer, signer},
+		{"VerifyWithPubKeyHex", func() {
+			VerifyWithPubKeyHex(pubKey, message, knownSignature)
+		}, pubKey, signer},
+		{"VerifyTolerant", func() {
+			VerifyTolerant(signer, message+"\n", knownSignature, &chaincfg.MainNetParams)
+		}, signer, signer},
+		{"VerifyBip137SignatureRaw", func() {
+			VerifyBip137SignatureRaw(signer, message, raw, &chaincfg.MainNetParams)
+		}, signer, signer},
+		{"VerifyAndRecover", func() {
+			VerifyAndRecover(signer, message, knownSignature, &chaincfg.MainNetParams)
+		}, signer, signer},
+		{"VerifyCommitment", func() {
+			VerifyCommitment(signer, MessageHash(message), knownSignature, &chaincfg.MainNetParams)
+		}, signer, signer},
+		{"VerifyPrefixed", func() {
+			VerifyPrefixed(signer, PreparedMessage(message), knownSignature, &chaincfg.MainNetParams)
+		}, signer, signer},
+		{"AddressVerifier.Verify", func() {
+			v, err := NewAddressVerifier(signer, &chaincfg.MainNetParams)
+			if err != nil {
+				t.Fatalf("NewAddressVerifier() error = %v", err)
+			}
+			v.Verify(message, knownSignature)
+		}, signer, signer},
+		{"VerifyBip137SignatureWithOptions with TrailingNewline", func() {
+			VerifyBip137SignatureWithOptions(testAddress, message, newlineSig, &chaincfg.MainNetParams, WithTrailingNewline())
+		}, testAddress, testAddress},
+		{"Client.Verify with TrailingNewline", func() {
+			NewClient(WithTrailingNewline()).Verify(SignedMessage{Address: testAddress, Message: message, Signature: newlineSig})
+		}, testAddress, testAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.verify()
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != 1 || lines[0] == "" {
+				t.Fatalf("audit log has %d lines, want 1:\n%s", len(lines), buf.String())
+			}
+			var got auditRecord
+			if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+				t.Fatalf("audit line is not JSON: %v", err)
+			}
+			if !got.Valid || got.Address != tt.wantAddress || got.Recovered != tt.wantRecovered {
+				t.Errorf("audit line = %+v, want valid record for %s recovering %s", got, tt.wantAddress, tt.wantRecovered)
+			}
+		})
+	}
+}
+
+func TestSetTelemetrySampleRate(t *testing.T) {
+	var buf bytes.Buffer
+	SetAuditLog(&buf)
+	defer SetAuditLog(nil)
+	defer SetTelemetrySampleRate(1)
+
+	tests := []struct {
+		name      string
+		rate      float64
+		wantLines int
+	}{
+		{name: "Rate 0 records nothing", rate: 0, wantLines: 0},
+		{name: "Rate 1 records everything", rate: 1, wantLines: 10},
+		{name: "Negative rate clamps to 0", rate: -1, wantLines: 0},
+		{name: "Rate above 1 clamps to 1", rate: 2, wantLines: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			SetTelemetrySampleRate(tt.rate)
+			for i := 0; i < 10; i++ {
+				VerifyBip137SignatureWithOptions(testAddress, "Hello, Bitcoin testing!", knownSignature, &chaincfg.MainNetParams)
+			}
+			if got := strings.Count(buf.String(), "\n"); got != tt.wantLines {
+				t.Errorf("audit log has %d lines, want %d", got, tt.wantLines)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package verify
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BatchStats summarizes how long each item of a batch took to verify
+type BatchStats struct {
+	Count int
+	Min   time.Duration
+	Max   time.Duration
+	P50   time.Duration
+	P99   time.Duration
+}
+
+// VerifyBatch verifies each message with a Client configured by opts and
+// returns one result per message, in order. Each result's Line is the 1-based
+// position of the message in msgs. Messages are verified concurrently, see
+// WithConcurrency.
+//
+// Unless WithVerifier is given, messages are verified by the built-in verifier
+// as in VerifyBip137SignatureWithOptions, not by the package verifier used by
+// VerifyBip137Signature. It is stricter: Electrum's compressed P2PKH header
+// bytes on segwit addresses are rejected unless WithLedgerCompat is given, and
+// BIP-0322 signatures are not supported. Pass WithVerifier(GetVerifier()) to
+// verify exactly as VerifyBip137Signature does.
+func VerifyBatch(msgs []SignedMessage, opts ...Option) []VerifyResult {
+	results, _ := verifyBatch(msgs, opts, false)
+	return results
+}
+
+// VerifyBatchWithStats is like VerifyBatch but also times each verification
+// and returns summary statistics, e.g. for capacity planning
+func VerifyBatchWithStats(msgs []SignedMessage, opts ...Option) ([]VerifyResult, BatchStats) {
+	return verifyBatch(msgs, opts, true)
+}
+
+// VerifyStream verifies messages received from in as they arrive and sends a
+// result for each on the returned channel, which is closed once in is closed
+// and all pending messages are verified. Results may arrive out of order; Line
+// is the 1-based position of the message in the stream. The caller must drain
+// the returned channel. Messages are verified as by VerifyBatch.
+func VerifyStream(in <-chan SignedMessage, opts ...Option) <-chan VerifyResult {
+	client := NewClient(opts...)
+	out := make(chan VerifyResult)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		position int
+	)
+	for range client.opts.workers() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				// Read and number messages under the lock so positions match
+				// the order of the stream
+				mu.Lock()
+				msg, ok := <-in
+				position++
+				line := position
+				mu.Unlock()
+				if !ok {
+					return
+				}
+
+				valid, err := client.Verify(msg)
+				out <- VerifyResult{Line: line, Message: msg, Valid: valid, Err: err}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// verifyBatch implements VerifyBatch and VerifyBatchWithStats
+func verifyBatch(msgs []SignedMessage, opts []Option, timed bool) ([]VerifyResult, BatchStats) {
+	client := NewClient(opts...)
+
+	results := make([]VerifyResult, len(msgs))
+	var durations []time.Duration
+	if timed {
+		durations = make([]time.Duration, len(msgs))
+	}
+
+	var (
+		wg   sync.WaitGroup
+		next atomic.Int64
+	)
+	for range min(client.opts.workers(), len(msgs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= len(msgs) {
+					return
+				}
+
+				start := time.Now()
+				valid, err := client.Verify(msgs[i])
+				if timed {
+					durations[i] = time.Since(start)
+				}
+				results[i] = VerifyResult{Line: i + 1, Message: msgs[i], Valid: valid, Err: err}
+			}
+		}()
+	}
+	wg.Wait()
+
+	LogDebug("Verified batch of %d messages", len(msgs))
+	return results, newBatchStats(durations)
+}
+
+// newBatchStats computes summary statistics over durations
+func newBatchStats(durations []time.Duration) BatchStats {
+	if len(durations) == 0 {
+		return BatchStats{}
+	}
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	return BatchStats{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Max:   sorted[len(sorted)-1],
+		P50:   percentile(sorted, 50),
+		P99:   percentile(sorted, 99),
+	}
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted durations
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank-1, 0)]
+}
@@ -0,0 +1,199 @@
+package verify
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestVerifyBatchWithStats(t *testing.T) {
+	valid := SignedMessage{Address: "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", Message: "Hello, Bitcoin testing!", Signature: knownSignature}
+	invalid := SignedMessage{Address: testAddress, Message: "Hello, Bitcoin testing!", Signature: knownSignature}
+
+	var msgs []SignedMessage
+	for i := 0; i < 100; i++ {
+		if i%2 == 0 {
+			msgs = append(msgs, valid)
+		} else {
+			msgs = append(msgs, invalid)
+		}
+	}
+
+	results, stats := VerifyBatchWithStats(msgs)
+	if len(results) != len(msgs) {
+		t.Fatalf("VerifyBatchWithStats() returned %d results, want %d", len(results), len(msgs))
+	}
+	for i, result := range results {
+		if result.Line != i+1 || result.Valid != (i%2 == 0) {
+			t.Errorf("result %d = %+v, want line %d valid %v", i, result, i+1, i%2 == 0)
+		}
+	}
+
+	if stats.Count != len(msgs) {
+		t.Errorf("BatchStats.Count = %d, want %d", stats.Count, len(msgs))
+	}
+	if stats.Min <= 0 || stats.Min > stats.P50 || stats.P50 > stats.P99 || stats.P99 > stats.Max {
+		t.Errorf("BatchStats = %+v, want 0 < Min <= P50 <= P99 <= Max", stats)
+	}
+	if stats.Max > time.Second {
+		t.Errorf("BatchStats.Max = %v, unexpectedly slow", stats.Max)
+	}
+
+	if got := VerifyBatch(nil); len(got) != 0 {
+		t.Errorf("VerifyBatch(nil) = %v, want no results", got)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	var sorted []time.Duration
+	for i := 1; i <= 200; i++ {
+		sorted = append(sorted, time.Duration(i))
+	}
+
+	if got := percentile(sorted, 50); got != 100 {
+		t.Errorf("percentile(50) = %d, want 100", got)
+	}
+	if got := percentile(sorted, 99); got != 198 {
+		t.Errorf("percentile(99) = %d, want 198", got)
+	}
+	if got := percentile(sorted[:1], 99); got != 1 {
+		t.Errorf("percentile(99) of one value = %d, want 1", got)
+	}
+}
+
+func TestWithConcurrency(t *testing.T) {
+	// inFlightVerifier records the largest number of concurrent calls
+	newInFlightVerifier := func(maxInFlight *atomic.Int32) Verifier {
+		var inFlight atomic.Int32
+		return VerifierFunc(func(msg SignedMessage, params *chaincfg.Params) (bool, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				current := maxInFlight.Load()
+				if n <= current || maxInFlight.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return true, nil
+		})
+	}
+
+	msgs := make([]SignedMessage, 8)
+	for i := range msgs {
+		msgs[i] = SignedMessage{Address: testAddress, Message: "Hello, Bitcoin testing!", Signature: knownSignature}
+	}
+
+	tests := []struct {
+		name        string
+		concurrency int
+		wantSerial  bool
+	}{
+		{"Serialized", 1, true},
+		{"Parallel", 4, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var batchMax atomic.Int32
+			VerifyBatch(msgs, WithConcurrency(tt.concurrency), WithVerifier(newInFlightVerifier(&batchMax)))
+
+			var streamMax atomic.Int32
+			in := make(chan SignedMessage)
+			go func() {
+				for _, msg := range msgs {
+					in <- msg
+				}
+				close(in)
+			}()
+			count := 0
+			for range VerifyStream(in, WithConcurrency(tt.concurrency), WithVerifier(newInFlightVerifier(&streamMax))) {
+				count++
+			}
+			if count != len(msgs) {
+				t.Errorf("VerifyStream() returned %d results, want %d", count, len(msgs))
+			}
+
+			for name, got := range map[string]int32{"VerifyBatch": batchMax.Load(), "VerifyStream": streamMax.Load()} {
+				if got > int32(tt.concurrency) {
+					t.Errorf("%s ran %d verifications at once, want at most %d", name, got, tt.concurrency)
+				}
+				if !tt.wantSerial && got < 2 {
+					t.Errorf("%s ran %d verifications at once, want several", name, got)
+				}
+			}
+		})
+	}
+}
+
+func TestWithConcurrencyClamp(t *testing.T) {
+	tests := []struct {
+		name            string
+		n               int
+		wantConcurrency int
+		wantWorkers     int
+	}{
+		{"Positive", 3, 3, 3},
+		{"Zero", 0, 0, runtime.GOMAXPROCS(0)},
+		{"Negative", -1, 0, runtime.GOMAXPROCS(0)},
+	}
+
+	msg := SignedMessage{Address: "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", Message: "Hello, Bitcoin testing!", Signature: knownSignature}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := newVerifyOptions(WithConcurrency(tt.n))
+			if options.Concurrency != tt.wantConcurrency {
+				t.Errorf("WithConcurrency(%d) Concurrency = %d, want %d", tt.n, options.Concurrency, tt.wantConcurrency)
+			}
+			if got := options.workers(); got != tt.wantWorkers {
+				t.Errorf("WithConcurrency(%d) workers() = %d, want %d", tt.n, got, tt.wantWorkers)
+			}
+			if got := VerifyBatch([]SignedMessage{msg}, WithConcurrency(tt.n)); len(got) != 1 || !got[0].Valid {
+				t.Errorf("VerifyBatch() with WithConcurrency(%d) = %+v, want one valid result", tt.n, got)
+			}
+		})
+	}
+}
+
+func TestVerifyBatchVerifier(t *testing.T) {
+	// Electrum signs segwit addresses with compressed P2PKH header bytes
+	signature, err := SignBip137Message(testPrivKey(t), "Hello, Bitcoin!", true)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+	msg := SignedMessage{Address: "bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc", Message: "Hello, Bitcoin!", Signature: signature}
+
+	if valid, err := VerifyBip137Signature(msg.Address, msg.Message, msg.Signature); err != nil || !valid {
+		t.Fatalf("VerifyBip137Signature() = %v, %v, want true, nil", valid, err)
+	}
+
+	tests := []struct {
+		name      string
+		opts      []Option
+		wantValid bool
+	}{
+		{"Built-in verifier", nil, false},
+		{"Built-in verifier with LedgerCompat", []Option{WithLedgerCompat()}, true},
+		{"Package verifier", []Option{WithVerifier(GetVerifier())}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyBatch([]SignedMessage{msg}, tt.opts...); got[0].Valid != tt.wantValid {
+				t.Errorf("VerifyBatch() valid = %v, want %v", got[0].Valid, tt.wantValid)
+			}
+
+			in := make(chan SignedMessage, 1)
+			in <- msg
+			close(in)
+			for result := range VerifyStream(in, tt.opts...) {
+				if result.Valid != tt.wantValid {
+					t.Errorf("VerifyStream() valid = %v, want %v", result.Valid, tt.wantValid)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package bip322
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// messageTag is the BIP-340 tag used to hash the signed message
+const messageTag = "BIP0322-signed-message"
+
+// Common errors that can occur during BIP-322 verification
+var (
+	ErrUnsupportedAddress = errors.New("unsupported address type for BIP-322 simple verification")
+	ErrInvalidWitness     = errors.New("invalid BIP-322 witness")
+)
+
+// VerifySimple verifies a BIP-322 "simple" signature for a P2WPKH or P2TR
+// address. A signature that is well formed but does not satisfy the address's
+// script is reported as invalid without an error.
+func VerifySimple(address, message, signatureBase64 string, params *chaincfg.Params) (bool, error) {
+	addr, err := btcutil.DecodeAddress(address, params)
+	if err != nil {
+		return false, fmt.Errorf("could not decode address: %w", err)
+	}
+	if !addr.IsForNet(params) {
+		return false, fmt.Errorf("address %s is not valid for network %s", address, params.Name)
+	}
+
+	switch addr.(type) {
+	case *btcutil.AddressWitnessPubKeyHash, *btcutil.AddressTaproot:
+	default:
+		return false, fmt.Errorf("%w: %T", ErrUnsupportedAddress, addr)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(signatureBase64)
+	if err != nil {
+		return false, fmt.Errorf("invalid base64 signature: %w", err)
+	}
+
+	witness, err := ParseWitness(signature)
+	if err != nil {
+		return false, err
+	}
+
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return false, fmt.Errorf("could not build output script: %w", err)
+	}
+
+	toSpend := buildToSpend([]byte(message), pkScript)
+	toSign := buildToSign(toSpend)
+	toSign.TxIn[0].Witness = witness
+
+	// The signature is valid if toSign correctly spends the output of toSpend
+	prevOutFetcher := txscript.NewCannedPrevOutputFetcher(pkScript, 0)
+	sigHashes := txscript.NewTxSigHashes(toSign, prevOutFetcher)
+	engine, err := txscript.NewEngine(pkScript, toSign, 0, txscript.StandardVerifyFlags,
+		nil, sigHashes, 0, prevOutFetcher)
+	if err != nil {
+		return false, fmt.Errorf("could not create script engine: %w", err)
+	}
+
+	if err := engine.Execute(); err != nil {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// ParseWitness decodes a consensus-serialized witness stack, which is the body
+// of a BIP-322 "simple" signature: a compact size item count followed by each
+// item as a compact size length and its bytes.
+func ParseWitness(data []byte) (wire.TxWitness, error) {
+	r := bytes.NewReader(data)
+
+	count, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWitness, err)
+	}
+	if count == 0 || count > uint64(len(data)) {
+		return nil, fmt.Errorf("%w: bad item count %d", ErrInvalidWitness, count)
+	}
+
+	witness := make(wire.TxWitness, 0, count)
+	for i := uint64(0); i < count; i++ {
+		item, err := wire.ReadVarBytes(r, 0, uint32(len(data)), "witness item")
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidWitness, err)
+		}
+		witness = append(witness, item)
+	}
+
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrInvalidWitness, r.Len())
+	}
+
+	return witness, nil
+}
+
+// buildToSpend builds the virtual to_spend transaction committing to the
+// message and paying to pkScript.
+func buildToSpend(message, pkScript []byte) *wire.MsgTx {
+	messageHash := chainhash.TaggedHash([]byte(messageTag), message)
+
+	// scriptSig is OP_0 PUSH32[message_hash]
+	scriptSig := make([]byte, 0, 34)
+	scriptSig = append(scriptSig, txscript.OP_0, txscript.OP_DATA_32)
+	scriptSig = append(scriptSig, messageHash[:]...)
+
+	tx := wire.NewMsgTx(0)
+	input := wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, 0xffffffff), scriptSig, nil)
+	input.Sequence = 0
+	tx.AddTxIn(input)
+	tx.AddTxOut(wire.NewTxOut(0, pkScript))
+
+	return tx
+}
+
+// buildToSign builds the virtual to_sign transaction spending toSpend
+func buildToSign(toSpend *wire.MsgTx) *wire.MsgTx {
+	toSpendHash := toSpend.TxHash()
+
+	tx := wire.NewMsgTx(0)
+	input := wire.NewTxIn(wire.NewOutPoint(&toSpendHash, 0), nil, nil)
+	input.Sequence = 0
+	tx.AddTxIn(input)
+	tx.AddTxOut(wire.NewTxOut(0, []byte{txscript.OP_RETURN}))
+
+	return tx
+}
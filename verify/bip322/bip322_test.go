@@ -0,0 +1,86 @@
+package bip322
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestVerifySimple(t *testing.T) {
+	// Vectors from https://github.com/bitcoin/bips/blob/master/bip-0322.mediawiki#test-vectors
+	tests := []struct {
+		name      string
+		address   string
+		message   string
+		signature string
+		wantValid bool
+		wantErr   bool
+	}{
+		{
+			name:      "P2WPKH empty message",
+			address:   "bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l",
+			message:   "",
+			signature: "AkcwRAIgM2gBAQqvZX15ZiysmKmQpDrG83avLIT492QBzLnQIxYCIBaTpOaD20qRlEylyxFSeEA2ba9YOixpX8z46TSDtS40ASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI=",
+			wantValid: true,
+		},
+		{
+			name:      "P2WPKH Hello World",
+			address:   "bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l",
+			message:   "Hello World",
+			signature: "AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtptFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI=",
+			wantValid: true,
+		},
+		{
+			name:      "P2WPKH wrong message",
+			address:   "bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l",
+			message:   "Hello World!",
+			signature: "AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtptFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI=",
+			wantValid: false,
+		},
+		{
+			name:      "P2TR Hello World",
+			address:   "bc1ppv609nr0vr25u07u95waq5lucwfm6tde4nydujnu8npg4q75mr5sxq8lt3",
+			message:   "Hello World",
+			signature: "AUHd69PrJQEv+oKTfZ8l+WROBHuy9HKrbFCJu7U1iK2iiEy1vMU5EfMtjc+VSHM7aU0SDbak5IUZRVno2P5mjSafAQ==",
+			wantValid: true,
+		},
+		{
+			name:      "P2TR wrong message",
+			address:   "bc1ppv609nr0vr25u07u95waq5lucwfm6tde4nydujnu8npg4q75mr5sxq8lt3",
+			message:   "Hello World - This should fail",
+			signature: "AUHd69PrJQEv+oKTfZ8l+WROBHuy9HKrbFCJu7U1iK2iiEy1vMU5EfMtjc+VSHM7aU0SDbak5IUZRVno2P5mjSafAQ==",
+			wantValid: false,
+		},
+		{
+			name:      "Malformed witness",
+			address:   "bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l",
+			message:   "Hello World",
+			signature: "AkcwRAIg",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, err := VerifySimple(tt.address, tt.message, tt.signature, &chaincfg.MainNetParams)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifySimple() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotValid != tt.wantValid {
+				t.Errorf("VerifySimple() = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestVerifySimpleUnsupportedAddress(t *testing.T) {
+	_, err := VerifySimple("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello World",
+		"AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtptFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI=",
+		&chaincfg.MainNetParams)
+	if !errors.Is(err, ErrUnsupportedAddress) {
+		t.Errorf("VerifySimple() error = %v, want ErrUnsupportedAddress", err)
+	}
+}
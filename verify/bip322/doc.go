@@ -0,0 +1,15 @@
+/*
+Package bip322 implements verification of BIP-322 "simple" signatures for
+single-key P2WPKH and P2TR addresses.
+
+BIP-322 proves control of an address by signing a virtual transaction that
+spends an output locked to the address. The "simple" format encodes only the
+witness stack of that transaction, base64-encoded. This package is kept
+separate from the BIP-0137 code in package verify, which handles the legacy
+65-byte recoverable signatures.
+
+Basic Usage:
+
+	valid, err := bip322.VerifySimple(address, message, signature, &chaincfg.MainNetParams)
+*/
+package bip322
@@ -0,0 +1,86 @@
+package verify
+
+import (
+	"iter"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// CandidateAddresses returns an iterator over every address that can be derived
+// from the key recovered from a signature, paired with its address type:
+//
+//	for addrType, address := range verify.CandidateAddresses(message, signature, params) {
+//		...
+//	}
+//
+// Segwit addresses are only yielded for compressed keys. If no key can be
+// recovered the iterator yields nothing; use RecoverPubKey to get the error.
+func CandidateAddresses(message, signatureBase64 string, params *chaincfg.Params) iter.Seq2[AddressType, string] {
+	return func(yield func(AddressType, string) bool) {
+		pubKey, compressed, err := RecoverPubKey(message, signatureBase64)
+		if err != nil {
+			LogDebug("No candidate addresses: %v", err)
+			return
+		}
+
+		for _, addrType := range SupportedAddressTypes() {
+			if addrType != AddressTypeP2PKH && !compressed {
+				continue
+			}
+
+			address, err := deriveAddress(pubKey, compressed, addrType, params)
+			if err != nil {
+				LogError("Failed to derive %s address: %v", addrType.String(), err)
+				continue
+			}
+
+			if !yield(addrType, address) {
+				return
+			}
+		}
+	}
+}
+
+// RecoverAddressesAllIDs returns the P2PKH address of the key recovered with
+// each of the four recovery IDs (0-3), keeping the signature's key
+// serialization, for finding which recovery ID produces an expected address.
+// Recovery IDs for which no key can be recovered are left out of the map.
+func RecoverAddressesAllIDs(message, signatureBase64 string, params *chaincfg.Params) (map[int]string, error) {
+	if message == "" {
+		return nil, ErrEmptyMessage
+	}
+
+	sigBytes, err := DecodeSignature(signatureBase64)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateHeaderByte(sigBytes[0]); err != nil {
+		return nil, err
+	}
+
+	compressed := sigBytes[0] >= 31
+	messageHash := hashBitcoinMessage(message, DefaultVerifyOptions())
+
+	addresses := make(map[int]string, 4)
+	for recoveryID := 0; recoveryID < 4; recoveryID++ {
+		header, err := headerByteFor(recoveryID, compressed, AddressTypeP2PKH)
+		if err != nil {
+			return nil, err
+		}
+		sigBytes[0] = header
+
+		pubKey, _, err := recoverPublicKey(sigBytes, messageHash)
+		if err != nil {
+			LogDebug("Recovery ID %d does not recover a key: %v", recoveryID, err)
+			continue
+		}
+
+		address, err := deriveAddress(pubKey, compressed, AddressTypeP2PKH, params)
+		if err != nil {
+			return nil, err
+		}
+		addresses[recoveryID] = address
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,79 @@
+package verify
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestCandidateAddresses(t *testing.T) {
+	tests := []struct {
+		name      string
+		message   string
+		signature string
+		want      map[AddressType]string
+	}{
+		{
+			name:      "Compressed key",
+			message:   "Hello, Bitcoin testing!",
+			signature: knownSignature,
+			want: map[AddressType]string{
+				AddressTypeP2PKH:      "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+				AddressTypeP2SHP2WPKH: "3Df8mboA4kSahFbXqA8BpSLZfv6V2gnqA8",
+				AddressTypeP2WPKH:     "bc1qtpl26utzhqurdeqhxe7s269hqzte504kqxavae",
+			},
+		},
+		{
+			name:      "Uncompressed key",
+			message:   "Signed with an uncompressed key",
+			signature: "HNREVP+ijXWttIk6GXlCzsKK8RhS3U3MZeA6t9zcYMu8FaldLWI4g/OZ3RZOtjglp9d2vjEHLXKBRvEyfOx/u9s=",
+			want: map[AddressType]string{
+				AddressTypeP2PKH: "1ELReFsTCUY2mfaDTy32qxYiT49z786eFg",
+			},
+		},
+		{
+			name:      "Invalid signature",
+			message:   "Hello, Bitcoin testing!",
+			signature: "Base64Signature==",
+			want:      map[AddressType]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[AddressType]string{}
+			for addrType, address := range CandidateAddresses(tt.message, tt.signature, &chaincfg.MainNetParams) {
+				got[addrType] = address
+			}
+
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("CandidateAddresses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecoverAddressesAllIDs(t *testing.T) {
+	got, err := RecoverAddressesAllIDs("Hello, Bitcoin testing!", knownSignature, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("RecoverAddressesAllIDs() error = %v", err)
+	}
+
+	// knownSignature has header byte 0x20, recovery ID 1
+	if got[1] != "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9" {
+		t.Errorf("RecoverAddressesAllIDs()[1] = %q, want 194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", got[1])
+	}
+	for recoveryID, address := range got {
+		if recoveryID < 0 || recoveryID > 3 {
+			t.Errorf("RecoverAddressesAllIDs() has recovery ID %d", recoveryID)
+		}
+		if recoveryID != 1 && address == got[1] {
+			t.Errorf("RecoverAddressesAllIDs()[%d] = %s, want an address other than recovery ID 1's", recoveryID, address)
+		}
+	}
+
+	if _, err := RecoverAddressesAllIDs("Hello, Bitcoin testing!", "", &chaincfg.MainNetParams); err == nil {
+		t.Errorf("RecoverAddressesAllIDs() error = nil, want an error for an empty signature")
+	}
+}
@@ -0,0 +1,31 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// VerifyAndCanonicalize verifies a BIP-0137 signature like
+// VerifyBip137SignatureWithParams and also returns the canonical encoding of
+// the address, e.g. lowercase for bech32 addresses entered in uppercase. The
+// canonical form is suitable for storing a signer's identity.
+func VerifyAndCanonicalize(address, message, signatureBase64 string, params *chaincfg.Params) (valid bool, canonicalAddress string, err error) {
+	if err := ValidateAddress(address, params); err != nil {
+		return false, "", err
+	}
+
+	addr, err := btcutil.DecodeAddress(address, params)
+	if err != nil {
+		return false, "", fmt.Errorf("could not decode address: %w", err)
+	}
+	canonicalAddress = addr.EncodeAddress()
+
+	valid, err = VerifyBip137SignatureWithParams(canonicalAddress, message, signatureBase64, params)
+	if err != nil {
+		return false, "", err
+	}
+
+	return valid, canonicalAddress, nil
+}
@@ -0,0 +1,42 @@
+package verify
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestVerifyAndCanonicalize(t *testing.T) {
+	const (
+		address   = "tb1qr97cuq4kvq7plfetmxnl6kls46xaka78n2288z"
+		message   = "The outage comes at a time when bitcoin has been fast approaching new highs not seen since June 26, 2019."
+		signature = "H/bSByRH7BW1YydfZlEx9x/nt4EAx/4A691CFlK1URbPEU5tJnTIu4emuzkgZFwC0ptvKuCnyBThnyLDCqPqT10="
+	)
+
+	tests := []struct {
+		name    string
+		address string
+	}{
+		{"Lowercase", address},
+		{"Uppercase", "TB1QR97CUQ4KVQ7PLFETMXNL6KLS46XAKA78N2288Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, canonical, err := VerifyAndCanonicalize(tt.address, message, signature, &chaincfg.TestNet3Params)
+			if err != nil {
+				t.Fatalf("VerifyAndCanonicalize() error = %v", err)
+			}
+			if !valid {
+				t.Errorf("VerifyAndCanonicalize() valid = false, want true")
+			}
+			if canonical != address {
+				t.Errorf("VerifyAndCanonicalize() canonical = %s, want %s", canonical, address)
+			}
+		})
+	}
+
+	if _, _, err := VerifyAndCanonicalize("Tb1qr97cuq4kvq7plfetmxnl6kls46xaka78n2288z", message, signature, &chaincfg.TestNet3Params); err == nil {
+		t.Errorf("VerifyAndCanonicalize() expected error for mixed-case address")
+	}
+}
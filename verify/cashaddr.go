@@ -0,0 +1,145 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// ErrInvalidCashAddr is returned when a Bitcoin Cash CashAddr address cannot be
+// decoded
+var ErrInvalidCashAddr = errors.New("invalid CashAddr address")
+
+// cashAddrNetworks maps CashAddr prefixes to the networks whose legacy address
+// encoding Bitcoin Cash shares
+var cashAddrNetworks = map[string]*chaincfg.Params{
+	"bitcoincash": &chaincfg.MainNetParams,
+	"bchtest":     &chaincfg.TestNet3Params,
+	"bchreg":      &chaincfg.RegressionNetParams,
+}
+
+// CashAddr version byte types
+const (
+	cashAddrTypeP2KH = 0
+	cashAddrTypeP2SH = 1
+)
+
+// cashAddrCharset is the base32 alphabet of CashAddr, shared with bech32
+const cashAddrCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// isCashAddr reports whether address starts with a known CashAddr prefix
+func isCashAddr(address string) bool {
+	prefix, _, ok := strings.Cut(address, ":")
+	_, known := cashAddrNetworks[strings.ToLower(prefix)]
+	return ok && known
+}
+
+// cashAddrToLegacy converts a P2KH CashAddr address, such as
+// "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", to the legacy
+// P2PKH address with the same hash and returns it with its network. Bitcoin
+// Cash signs messages with the same "Bitcoin Signed Message:\n" magic, so the
+// legacy address verifies like any other P2PKH address.
+func cashAddrToLegacy(address string) (string, *chaincfg.Params, error) {
+	prefix, addrType, hash, err := decodeCashAddr(address)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch addrType {
+	case cashAddrTypeP2KH:
+	case cashAddrTypeP2SH:
+		return "", nil, fmt.Errorf("%w: CashAddr P2SH", ErrUnsupportedAddressType)
+	default:
+		return "", nil, fmt.Errorf("%w: unknown address type %d", ErrInvalidCashAddr, addrType)
+	}
+
+	params := cashAddrNetworks[prefix]
+	legacy, err := btcutil.NewAddressPubKeyHash(hash, params)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrInvalidCashAddr, err)
+	}
+	return legacy.EncodeAddress(), params, nil
+}
+
+// decodeCashAddr decodes a CashAddr address into its lowercase prefix, its
+// address type and its 20-byte hash
+func decodeCashAddr(address string) (prefix string, addrType byte, hash []byte, err error) {
+	if strings.ToLower(address) != address && strings.ToUpper(address) != address {
+		return "", 0, nil, fmt.Errorf("%w: mixed case", ErrInvalidCashAddr)
+	}
+	address = strings.ToLower(address)
+
+	prefix, payload, ok := strings.Cut(address, ":")
+	if !ok || cashAddrNetworks[prefix] == nil {
+		return "", 0, nil, fmt.Errorf("%w: unknown prefix", ErrInvalidCashAddr)
+	}
+
+	// The checksum covers the low 5 bits of each prefix character, a zero
+	// separator and the payload including the 8 checksum characters
+	values := make([]byte, 0, len(prefix)+1+len(payload))
+	for _, c := range []byte(prefix) {
+		values = append(values, c&0x1f)
+	}
+	values = append(values, 0)
+	for _, c := range payload {
+		i := strings.IndexRune(cashAddrCharset, c)
+		if i < 0 {
+			return "", 0, nil, fmt.Errorf("%w: invalid character %q", ErrInvalidCashAddr, c)
+		}
+		values = append(values, byte(i))
+	}
+	if len(payload) <= 8 || cashAddrPolymod(values) != 0 {
+		return "", 0, nil, fmt.Errorf("%w: bad checksum", ErrInvalidCashAddr)
+	}
+
+	data := values[len(prefix)+1 : len(values)-8]
+	decoded, err := convertBits(data, 5, 8)
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("%w: %w", ErrInvalidCashAddr, err)
+	}
+
+	// The version byte holds the type in bits 3-6 and the hash size in bits
+	// 0-2; only 160-bit hashes are used by single-key addresses
+	if len(decoded) != 21 || decoded[0]&0x87 != 0 {
+		return "", 0, nil, fmt.Errorf("%w: unsupported version byte or hash size", ErrInvalidCashAddr)
+	}
+
+	return prefix, decoded[0] >> 3, decoded[1:], nil
+}
+
+// cashAddrPolymod computes the CashAddr BCH checksum of values; it is zero for
+// a valid address
+func cashAddrPolymod(values []byte) uint64 {
+	c := uint64(1)
+	for _, d := range values {
+		c0 := byte(c >> 35)
+		c = ((c & 0x07ffffffff) << 5) ^ uint64(d)
+		for i, generator := range []uint64{0x98f2bc8e61, 0x79b76d99e2, 0xf33e5fb3c4, 0xae2eabe2a8, 0x1e4f43e470} {
+			if c0&(1<<i) != 0 {
+				c ^= generator
+			}
+		}
+	}
+	return c ^ 1
+}
+
+// convertBits regroups 5-bit values into bytes, rejecting non-zero padding
+func convertBits(data []byte, fromBits, toBits uint) ([]byte, error) {
+	var acc, bits uint
+	var out []byte
+	for _, value := range data {
+		acc = acc<<fromBits | uint(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&(1<<toBits-1)))
+		}
+	}
+	if bits >= fromBits || acc&(1<<bits-1) != 0 {
+		return nil, errors.New("invalid padding")
+	}
+	return out, nil
+}
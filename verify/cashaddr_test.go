@@ -0,0 +1,87 @@
+package verify
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCashAddrToLegacy(t *testing.T) {
+	tests := []struct {
+		name        string
+		address     string
+		wantAddress string
+		wantNetwork string
+		wantErr     error
+	}{
+		{
+			name:        "Spec P2KH vector",
+			address:     "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+			wantAddress: "1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggu",
+			wantNetwork: "mainnet",
+		},
+		{
+			name:        "Uppercase",
+			address:     "BITCOINCASH:QPM2QSZNHKS23Z7629MMS6S4CWEF74VCWVY22GDX6A",
+			wantAddress: "1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggu",
+			wantNetwork: "mainnet",
+		},
+		{
+			name:        "Testnet",
+			address:     "bchtest:qzvsaasdvw6mt9j2rs3gyps673gj86flev3z0s40ln",
+			wantAddress: "muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz",
+			wantNetwork: "testnet3",
+		},
+		{
+			name:    "Bad checksum",
+			address: "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6b",
+			wantErr: ErrInvalidCashAddr,
+		},
+		{
+			name:    "Mixed case",
+			address: "bitcoincash:Qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+			wantErr: ErrInvalidCashAddr,
+		},
+		{
+			name:    "P2SH",
+			address: "bitcoincash:pqzw4g94mj9nntjhx852jk2rrmv9aazevuhj09fvv6",
+			wantErr: ErrUnsupportedAddressType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, params, err := cashAddrToLegacy(tt.address)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("cashAddrToLegacy() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("cashAddrToLegacy() unexpected error: %v", err)
+			}
+			if got != tt.wantAddress || params.Name != tt.wantNetwork {
+				t.Errorf("cashAddrToLegacy() = %s, %s, want %s, %s", got, params.Name, tt.wantAddress, tt.wantNetwork)
+			}
+		})
+	}
+}
+
+func TestVerifyBip137SignatureCashAddr(t *testing.T) {
+	message := "Hello, Bitcoin Cash!"
+	sig, err := SignBip137Message(testPrivKey(t), message, true)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+
+	// The CashAddr form of testAddress
+	valid, err := VerifyBip137Signature("bitcoincash:qzvsaasdvw6mt9j2rs3gyps673gj86flev4sthhcc0", message, sig)
+	if err != nil || !valid {
+		t.Errorf("VerifyBip137Signature() = %v, %v, want true, nil", valid, err)
+	}
+
+	_, err = VerifyBip137Signature("bitcoincash:qzvsaasdvw6mt9j2rs3gyps673gj86flev4sthhcc1", message, sig)
+	if !errors.Is(err, ErrInvalidCashAddr) {
+		t.Errorf("VerifyBip137Signature() error = %v, want ErrInvalidCashAddr", err)
+	}
+}
@@ -0,0 +1,42 @@
+package verify
+
+// Client verifies signed messages with its own configuration. Unlike the
+// package-level functions it does not read SetVerifier or any other global
+// state, so clients with different options can be used concurrently.
+type Client struct {
+	opts     VerifyOptions
+	verifier Verifier
+}
+
+// NewClient creates a Client configured by opts. Unless WithVerifier is given,
+// the client uses the built-in verifier configured with the same options.
+func NewClient(opts ...Option) *Client {
+	options := newVerifyOptions(opts...)
+
+	verifier := options.Verifier
+	if verifier == nil {
+		verifier = nativeVerifier{opts: options}
+	}
+
+	return &Client{
+		opts:     options,
+		verifier: verifier,
+	}
+}
+
+// Verify verifies msg on the client's network
+func (c *Client) Verify(msg SignedMessage) (bool, error) {
+	if msg.Address == "" {
+		return false, ErrEmptyAddress
+	}
+	if msg.Message == "" {
+		return false, ErrEmptyMessage
+	}
+	if msg.Signature == "" {
+		return false, ErrEmptySignature
+	}
+
+	return audited(msg, c.opts.Params, messageDigest(msg.Message, c.opts), func() (bool, error) {
+		return c.verifier.Verify(msg, c.opts.Params)
+	})
+}
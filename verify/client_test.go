@@ -0,0 +1,97 @@
+package verify
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestClientParallelNetworks(t *testing.T) {
+	message := "Hello from two networks"
+	signature, err := SignBip137Message(testPrivKey(t), message, true)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+
+	mainnet := NewClient(WithParams(&chaincfg.MainNetParams))
+	testnet := NewClient(WithParams(&chaincfg.TestNet3Params))
+
+	tests := []struct {
+		name      string
+		client    *Client
+		address   string
+		wantValid bool
+		wantErr   bool
+	}{
+		{
+			name:      "Mainnet client with mainnet address",
+			client:    mainnet,
+			address:   testAddress,
+			wantValid: true,
+		},
+		{
+			name:      "Testnet client with testnet address",
+			client:    testnet,
+			address:   "muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz",
+			wantValid: true,
+		},
+		{
+			name:    "Mainnet client with testnet address",
+			client:  mainnet,
+			address: "muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz",
+			wantErr: true,
+		},
+		{
+			name:    "Testnet client with mainnet address",
+			client:  testnet,
+			address: testAddress,
+			wantErr: true,
+		},
+	}
+
+	// Run every case many times from concurrent goroutines
+	var wg sync.WaitGroup
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				gotValid, err := tt.client.Verify(SignedMessage{
+					Address:   tt.address,
+					Message:   message,
+					Signature: signature,
+				})
+
+				if (err != nil) != tt.wantErr {
+					t.Errorf("%s: Client.Verify() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+					return
+				}
+				if gotValid != tt.wantValid {
+					t.Errorf("%s: Client.Verify() = %v, want %v", tt.name, gotValid, tt.wantValid)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+func TestClientWithVerifier(t *testing.T) {
+	var gotParams *chaincfg.Params
+	client := NewClient(
+		WithParams(&chaincfg.RegressionNetParams),
+		WithVerifier(VerifierFunc(func(msg SignedMessage, params *chaincfg.Params) (bool, error) {
+			gotParams = params
+			return true, nil
+		})),
+	)
+
+	valid, err := client.Verify(SignedMessage{Address: "a", Message: "m", Signature: "s"})
+	if err != nil || !valid {
+		t.Errorf("Client.Verify() = %v, %v; want true, nil", valid, err)
+	}
+	if gotParams != &chaincfg.RegressionNetParams {
+		t.Errorf("Client.Verify() passed params %v, want regtest", gotParams.Name)
+	}
+}
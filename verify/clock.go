@@ -0,0 +1,23 @@
+package verify
+
+import "time"
+
+// clock is the source of time for deadline handling, so that tests can
+// simulate deadlines without sleeping
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is a clock backed by the time package
+type realClock struct{}
+
+// Now implements clock
+func (realClock) Now() time.Time { return time.Now() }
+
+// After implements clock
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// timeSource is the clock used by VerifyBip137SignatureWithContext and
+// RateLimitedVerifier
+var timeSource clock = realClock{}
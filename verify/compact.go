@@ -0,0 +1,326 @@
+package verify
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// Compact signature layout defined by BIP-0137
+const (
+	// CompactSignatureLength is the length of a decoded compact signature: the
+	// header byte followed by the 32-byte R and S values
+	CompactSignatureLength = 65
+
+	// MinHeaderByte and MaxHeaderByte bound the header bytes defined by BIP-0137
+	MinHeaderByte = 27
+	MaxHeaderByte = 42
+)
+
+// Errors returned when a compact signature is malformed
+var (
+	ErrBadLength = errors.New("invalid signature length")
+	ErrBadHeader = errors.New("invalid signature header byte")
+
+	// ErrRecoveryFailed is returned when no public key can be recovered from a
+	// signature, e.g. because its R or S scalar is out of range. It differs from
+	// a valid signature that recovers a key which does not match the address.
+	ErrRecoveryFailed = errors.New("public key recovery failed")
+
+	// ErrNoMatchingHeader is returned by FixHeader when no header byte makes the
+	// signature verify against the target address
+	ErrNoMatchingHeader = errors.New("no header byte matches the target address")
+
+	// ErrUnexpectedDER is returned when a DER-encoded ECDSA signature, as used
+	// in transactions, is given where a compact BIP-0137 signature is expected
+	ErrUnexpectedDER = errors.New("DER-encoded signature given, expected a 65-byte compact signature (header byte, R, S) as produced by signmessage")
+)
+
+// SplitSignature decodes a base64 BIP-0137 signature and returns its header byte
+// and the 64-byte R||S value separately, so malformed signatures can be inspected
+// or given a different header. The header byte must be in the range 27-42.
+func SplitSignature(signatureBase64 string) (header byte, rs []byte, err error) {
+	sigBytes, err := DecodeSignature(signatureBase64)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if err := validateHeaderByte(sigBytes[0]); err != nil {
+		return 0, nil, err
+	}
+
+	return sigBytes[0], sigBytes[1:], nil
+}
+
+// FixHeader repairs a signature whose R||S is valid but whose header byte is
+// wrong. It tries every BIP-0137 header byte and returns the base64 signature
+// with the first header that verifies message against targetAddress.
+func FixHeader(signatureBase64, message string, targetAddress string, params *chaincfg.Params) (string, error) {
+	if targetAddress == "" {
+		return "", ErrEmptyAddress
+	}
+	if message == "" {
+		return "", ErrEmptyMessage
+	}
+	if signatureBase64 == "" {
+		return "", ErrEmptySignature
+	}
+
+	sigBytes, err := DecodeSignature(signatureBase64)
+	if err != nil {
+		return "", err
+	}
+
+	options := DefaultVerifyOptions()
+	candidate := make([]byte, len(sigBytes))
+	copy(candidate, sigBytes)
+
+	for header := byte(MinHeaderByte); header <= MaxHeaderByte; header++ {
+		candidate[0] = header
+		candidateBase64 := base64.StdEncoding.EncodeToString(candidate)
+
+		valid, err := verifyWithOptions(targetAddress, message, candidateBase64, params, options)
+		if err != nil {
+			LogTrace("Header byte 0x%02x rejected: %v", header, err)
+			continue
+		}
+		if valid {
+			LogDebug("Header byte 0x%02x matches %s (was 0x%02x)", header, targetAddress, sigBytes[0])
+			return candidateBase64, nil
+		}
+	}
+
+	return "", ErrNoMatchingHeader
+}
+
+// RecoverPubKey recovers the public key that produced a BIP-0137 signature over
+// message, along with whether the signature commits to the compressed form of
+// the key. It returns an error wrapping ErrRecoveryFailed when the signature
+// does not correspond to any key.
+func RecoverPubKey(message, signatureBase64 string) (*btcec.PublicKey, bool, error) {
+	if message == "" {
+		return nil, false, ErrEmptyMessage
+	}
+	if signatureBase64 == "" {
+		return nil, false, ErrEmptySignature
+	}
+
+	sigBytes, err := DecodeSignature(signatureBase64)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return recoverPublicKey(sigBytes, hashBitcoinMessage(message, DefaultVerifyOptions()))
+}
+
+// RecoverPubKeyHex recovers the public key like RecoverPubKey and returns it
+// hex-encoded in the 33-byte compressed or 65-byte uncompressed form, as
+// requested, regardless of the form the signature commits to
+func RecoverPubKeyHex(message, signatureBase64 string, compressed bool) (string, error) {
+	pubKey, _, err := RecoverPubKey(message, signatureBase64)
+	if err != nil {
+		return "", err
+	}
+
+	if compressed {
+		return hex.EncodeToString(pubKey.SerializeCompressed()), nil
+	}
+	return hex.EncodeToString(pubKey.SerializeUncompressed()), nil
+}
+
+// CanonicalizeSignature returns the low-S form of a compact signature. When S
+// is in the upper half of the curve order it is replaced by N-S and the parity
+// bit of the recovery ID is flipped, so the signature still recovers the same
+// key. Signatures already in low-S form are re-encoded unchanged.
+func CanonicalizeSignature(signatureBase64 string) (string, error) {
+	sigBytes, err := DecodeSignature(signatureBase64)
+	if err != nil {
+		return "", err
+	}
+	if err := validateHeaderByte(sigBytes[0]); err != nil {
+		return "", err
+	}
+
+	var s btcec.ModNScalar
+	if overflow := s.SetByteSlice(sigBytes[33:65]); overflow || s.IsZero() {
+		return "", fmt.Errorf("%w: S is out of range", ErrRecoveryFailed)
+	}
+
+	if s.IsOverHalfOrder() {
+		s.Negate()
+		s.PutBytesUnchecked(sigBytes[33:65])
+
+		recoveryID := (sigBytes[0] - 27) & 3
+		sigBytes[0] = sigBytes[0] - recoveryID + (recoveryID ^ 1)
+	}
+
+	return base64.StdEncoding.EncodeToString(sigBytes), nil
+}
+
+// NormalizeSignature decodes a compact signature given as standard base64, as
+// URL-safe base64, with or without padding, or as hex, and returns it in the
+// standard padded base64 encoding, so signatures can be stored and compared in
+// one form. The signature bytes are not changed; see CanonicalizeSignature for
+// the low-S form.
+func NormalizeSignature(signature string) (string, error) {
+	signature = stripWhitespace(signature)
+
+	sigBytes, err := decodeSignatureEncoding(signature)
+	if err != nil {
+		return "", fmt.Errorf("invalid signature encoding: %w", err)
+	}
+
+	if isDERSignature(sigBytes) {
+		return "", ErrUnexpectedDER
+	}
+	if len(sigBytes) != CompactSignatureLength {
+		return "", fmt.Errorf("%w: %d (expected %d bytes)", ErrBadLength, len(sigBytes), CompactSignatureLength)
+	}
+
+	return base64.StdEncoding.EncodeToString(sigBytes), nil
+}
+
+// decodeSignatureEncoding decodes s as hex when it has the length of a hex
+// compact signature, and otherwise as any of the base64 variants
+func decodeSignatureEncoding(s string) ([]byte, error) {
+	if len(s) == 2*CompactSignatureLength {
+		if b, err := hex.DecodeString(s); err == nil {
+			return b, nil
+		}
+	}
+
+	var firstErr error
+	for _, encoding := range []*base64.Encoding{
+		base64.StdEncoding,
+		base64.RawStdEncoding,
+		base64.URLEncoding,
+		base64.RawURLEncoding,
+	} {
+		b, err := encoding.DecodeString(s)
+		if err == nil {
+			return b, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+// RecoveredHash160 returns the hash160 of the public key that produced a
+// BIP-0137 signature, serialized in the form the header byte commits to, and
+// whether that form is compressed. It is enough to compare against the
+// witness program or pubkey hash of an address without encoding it.
+func RecoveredHash160(message, signatureBase64 string) ([20]byte, bool, error) {
+	var hash [20]byte
+
+	pubKey, compressed, err := RecoverPubKey(message, signatureBase64)
+	if err != nil {
+		return hash, false, err
+	}
+
+	serialized := pubKey.SerializeUncompressed()
+	if compressed {
+		serialized = pubKey.SerializeCompressed()
+	}
+	copy(hash[:], btcutil.Hash160(serialized))
+
+	return hash, compressed, nil
+}
+
+// DecodeSignature decodes a base64 compact signature and checks that it is 65
+// bytes long. Whitespace anywhere in the input is ignored, so signatures that
+// were wrapped or indented when copied from an email still decode.
+func DecodeSignature(signatureBase64 string) ([]byte, error) {
+	return decodeSignature(signatureBase64, false)
+}
+
+// decodeSignature implements DecodeSignature. With lenientLength, a signature
+// longer than 65 bytes is truncated to its first 65 bytes.
+func decodeSignature(signatureBase64 string, lenientLength bool) ([]byte, error) {
+	sigBytes, err := base64.StdEncoding.DecodeString(stripWhitespace(signatureBase64))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 signature: %w", err)
+	}
+
+	if isDERSignature(sigBytes) {
+		return nil, ErrUnexpectedDER
+	}
+
+	if lenientLength && len(sigBytes) > CompactSignatureLength {
+		LogWarning("Ignoring %d bytes after the %d-byte signature", len(sigBytes)-CompactSignatureLength, CompactSignatureLength)
+		sigBytes = sigBytes[:CompactSignatureLength]
+	}
+
+	if len(sigBytes) != CompactSignatureLength {
+		return nil, fmt.Errorf("%w: %d (expected %d bytes)", ErrBadLength, len(sigBytes), CompactSignatureLength)
+	}
+
+	return sigBytes, nil
+}
+
+// isDERSignature reports whether b is a DER-encoded ECDSA signature. Its first
+// byte, the DER sequence tag 0x30, is never a valid compact header byte.
+func isDERSignature(b []byte) bool {
+	if len(b) == 0 || b[0] != 0x30 {
+		return false
+	}
+	_, err := ecdsa.ParseDERSignature(b)
+	return err == nil
+}
+
+// stripWhitespace removes all Unicode whitespace from s
+func stripWhitespace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// validateHeaderByte checks that a header byte is one defined by BIP-0137
+func validateHeaderByte(headerByte byte) error {
+	if headerByte < MinHeaderByte || headerByte > MaxHeaderByte {
+		LogError("Invalid header byte: 0x%02x", headerByte)
+		return fmt.Errorf("%w: 0x%02x", ErrBadHeader, headerByte)
+	}
+	return nil
+}
+
+// recoverPublicKey recovers the public key from a 65-byte compact signature over
+// messageHash. BIP-0137 segwit header bytes (35-42) are mapped back onto the
+// compressed recovery codes understood by btcec.
+func recoverPublicKey(sigBytes, messageHash []byte) (*btcec.PublicKey, bool, error) {
+	headerByte := sigBytes[0]
+	if err := validateHeaderByte(headerByte); err != nil {
+		return nil, false, err
+	}
+
+	recoveryID := (headerByte - 27) % 4
+	compressed := headerByte >= 31
+	LogDebug("Recovery ID: %d, Compressed: %t", recoveryID, compressed)
+
+	compact := make([]byte, len(sigBytes))
+	copy(compact, sigBytes)
+	compact[0] = 27 + recoveryID
+	if compressed {
+		compact[0] += 4
+	}
+
+	pubKey, wasCompressed, err := ecdsa.RecoverCompact(compact, messageHash)
+	if err != nil {
+		LogError("Public key recovery failed: %v", err)
+		return nil, false, fmt.Errorf("%w: %w", ErrRecoveryFailed, err)
+	}
+
+	return pubKey, wasCompressed, nil
+}
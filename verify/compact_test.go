@@ -0,0 +1,425 @@
+package verify
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// knownSignature is the signature of "Hello, Bitcoin testing!" by
+// 194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9 used throughout the tests
+const knownSignature = "IOeVH/0KqgmS3XKwqCJiwlcHonwxKMQN6fbOW5UsXSDZB4EGCVTXx6c+ZU/Ae5qO94MSBZn2aPOiUsupRIwBaAU="
+
+func TestSplitSignature(t *testing.T) {
+	raw, _ := base64.StdEncoding.DecodeString(knownSignature)
+
+	header, rs, err := SplitSignature(knownSignature)
+	if err != nil {
+		t.Fatalf("SplitSignature() error = %v", err)
+	}
+	if header != 0x20 {
+		t.Errorf("SplitSignature() header = 0x%02x, want 0x20", header)
+	}
+	if len(rs) != 64 || !bytes.Equal(rs, raw[1:]) {
+		t.Errorf("SplitSignature() rs = %x, want %x", rs, raw[1:])
+	}
+
+	tests := []struct {
+		name    string
+		input   []byte
+		wantErr error
+	}{
+		{
+			name:    "63-byte signature",
+			input:   raw[:63],
+			wantErr: ErrBadLength,
+		},
+		{
+			name:    "Header byte out of range",
+			input:   append([]byte{0x1a}, raw[1:]...),
+			wantErr: ErrBadHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := SplitSignature(base64.StdEncoding.EncodeToString(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SplitSignature() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFixHeader(t *testing.T) {
+	raw, _ := base64.StdEncoding.DecodeString(knownSignature)
+
+	tests := []struct {
+		name    string
+		header  byte
+		address string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "Uncompressed header for compressed key",
+			header:  0x1b,
+			address: "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			want:    knownSignature,
+		},
+		{
+			name:    "Out-of-range header",
+			header:  0x00,
+			address: "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			want:    knownSignature,
+		},
+		{
+			name:    "Address that did not sign",
+			header:  0x1b,
+			address: testAddress,
+			wantErr: ErrNoMatchingHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			corrupted := append([]byte{tt.header}, raw[1:]...)
+
+			got, err := FixHeader(base64.StdEncoding.EncodeToString(corrupted), "Hello, Bitcoin testing!", tt.address, &chaincfg.MainNetParams)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FixHeader() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("FixHeader() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecoverPubKey(t *testing.T) {
+	raw, _ := base64.StdEncoding.DecodeString(knownSignature)
+
+	pubKey, compressed, err := RecoverPubKey("Hello, Bitcoin testing!", knownSignature)
+	if err != nil {
+		t.Fatalf("RecoverPubKey() error = %v", err)
+	}
+	if got := hex.EncodeToString(pubKey.SerializeCompressed()); got != "034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23c" {
+		t.Errorf("RecoverPubKey() key = %s", got)
+	}
+	if !compressed {
+		t.Errorf("RecoverPubKey() compressed = false, want true")
+	}
+
+	// R and S equal to 0xff..ff are both larger than the curve order
+	outOfRange := append([]byte{raw[0]}, bytes.Repeat([]byte{0xff}, 64)...)
+	zeroR := append(append([]byte{raw[0]}, make([]byte, 32)...), raw[33:]...)
+
+	for name, signature := range map[string][]byte{
+		"Scalars out of range": outOfRange,
+		"Zero R":               zeroR,
+	} {
+		t.Run(name, func(t *testing.T) {
+			sig := base64.StdEncoding.EncodeToString(signature)
+
+			_, _, err := RecoverPubKey("Hello, Bitcoin testing!", sig)
+			if !errors.Is(err, ErrRecoveryFailed) {
+				t.Errorf("RecoverPubKey() error = %v, want ErrRecoveryFailed", err)
+			}
+
+			_, err = VerifyBip137SignatureWithOptions("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", sig, &chaincfg.MainNetParams)
+			if !errors.Is(err, ErrRecoveryFailed) {
+				t.Errorf("VerifyBip137SignatureWithOptions() error = %v, want ErrRecoveryFailed", err)
+			}
+
+			_, err = VerifyBip137SignatureWithParams("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", sig, &chaincfg.MainNetParams)
+			if !errors.Is(err, ErrRecoveryFailed) {
+				t.Errorf("VerifyBip137SignatureWithParams() error = %v, want ErrRecoveryFailed", err)
+			}
+		})
+	}
+}
+
+func TestRecoverPubKeyHex(t *testing.T) {
+	tests := []struct {
+		name       string
+		compressed bool
+		want       string
+	}{
+		{"Compressed", true, "034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23c"},
+		{"Uncompressed", false, "044fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23cf1814f2c277aa4c700dfc671b7f8e86197daaa2cb93b4dc782a378633f78f869"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RecoverPubKeyHex("Hello, Bitcoin testing!", knownSignature, tt.compressed)
+			if err != nil {
+				t.Fatalf("RecoverPubKeyHex() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RecoverPubKeyHex() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := RecoverPubKeyHex("Hello, Bitcoin testing!", "", true); !errors.Is(err, ErrEmptySignature) {
+		t.Errorf("RecoverPubKeyHex() error = %v, want ErrEmptySignature", err)
+	}
+}
+
+func TestRecoveredHash160(t *testing.T) {
+	tests := []struct {
+		name           string
+		address        string
+		message        string
+		signature      string
+		wantCompressed bool
+	}{
+		{"Compressed", "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", knownSignature, true},
+		{"Uncompressed", "1ELReFsTCUY2mfaDTy32qxYiT49z786eFg", "Signed with an uncompressed key", "HNREVP+ijXWttIk6GXlCzsKK8RhS3U3MZeA6t9zcYMu8FaldLWI4g/OZ3RZOtjglp9d2vjEHLXKBRvEyfOx/u9s=", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := btcutil.DecodeAddress(tt.address, &chaincfg.MainNetParams)
+			if err != nil {
+				t.Fatalf("DecodeAddress() error = %v", err)
+			}
+
+			hash, compressed, err := RecoveredHash160(tt.message, tt.signature)
+			if err != nil {
+				t.Fatalf("RecoveredHash160() error = %v", err)
+			}
+			if !bytes.Equal(hash[:], addr.ScriptAddress()) {
+				t.Errorf("RecoveredHash160() = %x, want %x", hash, addr.ScriptAddress())
+			}
+			if compressed != tt.wantCompressed {
+				t.Errorf("RecoveredHash160() compressed = %v, want %v", compressed, tt.wantCompressed)
+			}
+		})
+	}
+}
+
+func TestDecodeSignature(t *testing.T) {
+	// Wrapped across two lines and indented, as when copied from an email
+	wrapped := "  " + knownSignature[:44] + "\r\n\t" + knownSignature[44:] + " \n"
+
+	got, err := DecodeSignature(wrapped)
+	if err != nil {
+		t.Fatalf("DecodeSignature() error = %v", err)
+	}
+	want, _ := base64.StdEncoding.DecodeString(knownSignature)
+	if !bytes.Equal(got, want) {
+		t.Errorf("DecodeSignature() = %x, want %x", got, want)
+	}
+
+	valid, err := VerifyBip137SignatureWithOptions("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", wrapped, &chaincfg.MainNetParams)
+	if err != nil || !valid {
+		t.Errorf("VerifyBip137SignatureWithOptions() = %v, %v; want true, nil", valid, err)
+	}
+
+	valid, err = VerifyBip137Signature("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", wrapped)
+	if err != nil || !valid {
+		t.Errorf("VerifyBip137Signature() = %v, %v; want true, nil", valid, err)
+	}
+
+	if _, err := DecodeSignature(knownSignature[:40]); !errors.Is(err, ErrBadLength) {
+		t.Errorf("DecodeSignature() error = %v, want ErrBadLength", err)
+	}
+}
+
+func FuzzDecodeSignature(f *testing.F) {
+	f.Add(knownSignature, false)
+	f.Add(knownSignature[:64]+"\n"+knownSignature[64:]+"\n", false)
+	f.Add(knownSignature[:86]+"AAAA", true)
+	f.Add(knownSignature[:40], false)
+	f.Add("MEQCIA==", false)
+
+	f.Fuzz(func(t *testing.T, signature string, lenientLength bool) {
+		sigBytes, err := decodeSignature(signature, lenientLength)
+		if err != nil {
+			return
+		}
+
+		if len(sigBytes) != CompactSignatureLength {
+			t.Fatalf("decodeSignature() returned %d bytes, want %d", len(sigBytes), CompactSignatureLength)
+		}
+
+		// Accepted signatures are the leading bytes of the whitespace-free base64
+		full, err := base64.StdEncoding.DecodeString(stripWhitespace(signature))
+		if err != nil {
+			t.Fatalf("decodeSignature() accepted invalid base64 %q", signature)
+		}
+		if !bytes.HasPrefix(full, sigBytes) || (!lenientLength && len(full) != len(sigBytes)) {
+			t.Fatalf("decodeSignature() = %x, want a prefix of %x", sigBytes, full)
+		}
+	})
+}
+
+func TestDecodeSignaturePEMWrapped(t *testing.T) {
+	// PEM wraps base64 at 64 characters per line
+	for name, newline := range map[string]string{"LF": "\n", "CRLF": "\r\n"} {
+		t.Run(name, func(t *testing.T) {
+			wrapped := knownSignature[:64] + newline + knownSignature[64:] + newline
+
+			if _, err := DecodeSignature(wrapped); err != nil {
+				t.Errorf("DecodeSignature() error = %v", err)
+			}
+
+			valid, err := VerifyBip137Signature("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", wrapped)
+			if err != nil || !valid {
+				t.Errorf("VerifyBip137Signature() = %v, %v; want true, nil", valid, err)
+			}
+
+			valid, err = VerifyBip137SignatureWithOptions("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", wrapped, &chaincfg.MainNetParams)
+			if err != nil || !valid {
+				t.Errorf("VerifyBip137SignatureWithOptions() = %v, %v; want true, nil", valid, err)
+			}
+		})
+	}
+}
+
+func TestCanonicalizeSignature(t *testing.T) {
+	raw, _ := base64.StdEncoding.DecodeString(knownSignature)
+
+	// Build the high-S twin of knownSignature: S' = N - S with the recovery
+	// ID parity flipped
+	var s btcec.ModNScalar
+	s.SetByteSlice(raw[33:65])
+	s.Negate()
+	highS := bytes.Clone(raw)
+	s.PutBytesUnchecked(highS[33:65])
+	highS[0] = raw[0] - 1 // recovery ID 1 becomes 0
+	highSignature := base64.StdEncoding.EncodeToString(highS)
+
+	for name, signature := range map[string]string{"High S": highSignature, "Low S": knownSignature} {
+		t.Run(name, func(t *testing.T) {
+			valid, err := VerifyBip137SignatureWithOptions("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", signature, &chaincfg.MainNetParams)
+			if err != nil || !valid {
+				t.Errorf("VerifyBip137SignatureWithOptions() = %v, %v; want true, nil", valid, err)
+			}
+
+			got, err := CanonicalizeSignature(signature)
+			if err != nil {
+				t.Fatalf("CanonicalizeSignature() error = %v", err)
+			}
+			if got != knownSignature {
+				t.Errorf("CanonicalizeSignature() = %s, want %s", got, knownSignature)
+			}
+		})
+	}
+
+	if _, err := CanonicalizeSignature("AAAA"); !errors.Is(err, ErrBadLength) {
+		t.Errorf("CanonicalizeSignature() error = %v, want ErrBadLength", err)
+	}
+}
+
+func TestNormalizeSignature(t *testing.T) {
+	raw, _ := base64.StdEncoding.DecodeString(knownSignature)
+
+	tests := []struct {
+		name      string
+		signature string
+		wantErr   error
+	}{
+		{name: "Standard", signature: knownSignature},
+		{name: "URL-safe unpadded", signature: base64.RawURLEncoding.EncodeToString(raw)},
+		{name: "URL-safe padded", signature: base64.URLEncoding.EncodeToString(raw)},
+		{name: "Standard unpadded", signature: base64.RawStdEncoding.EncodeToString(raw)},
+		{name: "Hex", signature: hex.EncodeToString(raw)},
+		{name: "Wrapped", signature: knownSignature[:44] + "\n" + knownSignature[44:]},
+		{name: "Too short", signature: "AAAA", wantErr: ErrBadLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeSignature(tt.signature)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("NormalizeSignature() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeSignature() error = %v", err)
+			}
+			if got != knownSignature {
+				t.Errorf("NormalizeSignature() = %s, want %s", got, knownSignature)
+			}
+		})
+	}
+
+	if _, err := NormalizeSignature("not a signature!"); err == nil {
+		t.Errorf("NormalizeSignature() error = nil, want an encoding error")
+	}
+}
+
+func TestUnexpectedDER(t *testing.T) {
+	// A transaction-style DER signature of the message digest
+	hash := hashBitcoinMessage("Hello, Bitcoin testing!", DefaultVerifyOptions())
+	der := base64.StdEncoding.EncodeToString(ecdsa.Sign(testPrivKey(t), hash).Serialize())
+
+	if _, err := DecodeSignature(der); !errors.Is(err, ErrUnexpectedDER) {
+		t.Errorf("DecodeSignature() error = %v, want ErrUnexpectedDER", err)
+	}
+
+	_, err := VerifyBip137SignatureWithOptions(testAddress, "Hello, Bitcoin testing!", der, &chaincfg.MainNetParams)
+	if !errors.Is(err, ErrUnexpectedDER) {
+		t.Errorf("VerifyBip137SignatureWithOptions() error = %v, want ErrUnexpectedDER", err)
+	}
+
+	_, err = VerifyBip137Signature(testAddress, "Hello, Bitcoin testing!", der)
+	if !errors.Is(err, ErrUnexpectedDER) {
+		t.Fatalf("VerifyBip137Signature() error = %v, want ErrUnexpectedDER", err)
+	}
+	if !strings.Contains(err.Error(), "compact signature") {
+		t.Errorf("VerifyBip137Signature() error = %q, want guidance on the expected format", err)
+	}
+}
+
+func TestBtcecErrorsPreserved(t *testing.T) {
+	// R is zero, which both DER parsing and key recovery reject
+	sig, _ := base64.StdEncoding.DecodeString(knownSignature)
+	copy(sig[1:33], make([]byte, 32))
+	zeroR := base64.StdEncoding.EncodeToString(sig)
+
+	tests := []struct {
+		name   string
+		verify func() error
+	}{
+		{"RecoverPubKey", func() error {
+			_, _, err := RecoverPubKey("Hello, Bitcoin testing!", zeroR)
+			return err
+		}},
+		{"VerifyBip137SignatureWithOptions", func() error {
+			_, err := VerifyBip137SignatureWithOptions("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", zeroR, &chaincfg.MainNetParams)
+			return err
+		}},
+		{"VerifyBip137SignatureWithParams", func() error {
+			_, err := VerifyBip137SignatureWithParams("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", zeroR, &chaincfg.MainNetParams)
+			return err
+		}},
+		{"VerifyWithPubKeyHex", func() error {
+			_, err := VerifyWithPubKeyHex("034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23c", "Hello, Bitcoin testing!", zeroR)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.verify()
+			var kind ecdsa.ErrorKind
+			if !errors.As(err, &kind) {
+				t.Errorf("%s() error = %v, want it to wrap a btcec ecdsa error", tt.name, err)
+			}
+		})
+	}
+}
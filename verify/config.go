@@ -0,0 +1,34 @@
+package verify
+
+import "io"
+
+// Config holds the package-wide settings that are otherwise applied with
+// SetLogLevel, Logger.SetOutput, SetRedactLogs and SetVerifier.
+type Config struct {
+	// LogLevel is the logging verbosity
+	LogLevel LogLevel
+
+	// LogOutput receives log output. A nil writer discards all logs.
+	LogOutput io.Writer
+
+	// RedactLogs redacts message contents and signature bytes in log output,
+	// see SetRedactLogs
+	RedactLogs bool
+
+	// Verifier is used by VerifyBip137SignatureWithParams. A nil verifier
+	// selects the default external verifier.
+	Verifier Verifier
+}
+
+// Configure applies all settings in cfg in one call
+func Configure(cfg Config) {
+	output := cfg.LogOutput
+	if output == nil {
+		output = io.Discard
+	}
+
+	SetLogLevel(cfg.LogLevel)
+	Logger.SetOutput(output)
+	SetRedactLogs(cfg.RedactLogs)
+	SetVerifier(cfg.Verifier)
+}
@@ -0,0 +1,74 @@
+package verify
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestConfigure(t *testing.T) {
+	t.Cleanup(func() {
+		Configure(Config{LogLevel: LogLevelInfo, LogOutput: os.Stdout})
+	})
+
+	var buf bytes.Buffer
+	called := false
+	Configure(Config{
+		LogLevel:  LogLevelDebug,
+		LogOutput: &buf,
+		Verifier: VerifierFunc(func(msg SignedMessage, params *chaincfg.Params) (bool, error) {
+			called = true
+			return true, nil
+		}),
+	})
+
+	if got := GetLogLevel(); got != LogLevelDebug {
+		t.Errorf("GetLogLevel() = %v, want %v", got, LogLevelDebug)
+	}
+
+	LogDebug("configured")
+	if !strings.Contains(buf.String(), "[DEBUG] configured") {
+		t.Errorf("log output = %q, want it to contain the debug line", buf.String())
+	}
+
+	if valid, err := VerifyBip137Signature("a", "m", "s"); err != nil || !valid || !called {
+		t.Errorf("VerifyBip137Signature() = %v, %v; want configured verifier to be used", valid, err)
+	}
+
+	// A nil writer and verifier select the defaults
+	Configure(Config{LogLevel: LogLevelError})
+	if got := GetLogLevel(); got != LogLevelError {
+		t.Errorf("GetLogLevel() = %v, want %v", got, LogLevelError)
+	}
+	if Logger.Writer() != io.Discard {
+		t.Errorf("Logger.Writer() = %v, want io.Discard", Logger.Writer())
+	}
+	if _, ok := GetVerifier().(externalVerifier); !ok {
+		t.Errorf("GetVerifier() = %T, want the default verifier", GetVerifier())
+	}
+}
+
+func TestSetVerifierNil(t *testing.T) {
+	t.Cleanup(func() { SetVerifier(nil) })
+
+	SetVerifier(nil)
+	if _, ok := GetVerifier().(externalVerifier); !ok {
+		t.Errorf("GetVerifier() = %T, want the default verifier", GetVerifier())
+	}
+	valid, err := VerifyBip137Signature("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", knownSignature)
+	if err != nil || !valid {
+		t.Errorf("VerifyBip137Signature() = %v, %v, want true, nil", valid, err)
+	}
+
+	// A nil function wrapped as a Verifier is not nil itself
+	SetVerifier(VerifierFunc(nil))
+	_, err = VerifyBip137Signature("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", knownSignature)
+	if !errors.Is(err, ErrNoVerifier) {
+		t.Errorf("VerifyBip137Signature() error = %v, want ErrNoVerifier", err)
+	}
+}
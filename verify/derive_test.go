@@ -0,0 +1,74 @@
+package verify
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestDeriveAddressAcrossNetworks(t *testing.T) {
+	pubKey := testPrivKey(t).PubKey()
+
+	tests := []struct {
+		params       *chaincfg.Params
+		p2pkh        string
+		p2shP2wpkh   string
+		p2wpkh       string
+		uncompressed string
+	}{
+		{
+			params:       &chaincfg.MainNetParams,
+			p2pkh:        "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG",
+			p2shP2wpkh:   "3291hXxutb58vbDVVumaJpopanmfxjVpgJ",
+			p2wpkh:       "bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc",
+			uncompressed: "1ELReFsTCUY2mfaDTy32qxYiT49z786eFg",
+		},
+		{
+			params:       &chaincfg.TestNet3Params,
+			p2pkh:        "muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz",
+			p2shP2wpkh:   "2MshDmGtwW3aV8Nr3B3PSvmo5o8yqhwJFKZ",
+			p2wpkh:       "tb1qny80vrtrkk6evjsuy2pqvxh52y37j07t27zcrt",
+			uncompressed: "mtrNwJxS1VyHYn3qBY1Qfsm3K3kh1mGRMS",
+		},
+		{
+			params:       &chaincfg.RegressionNetParams,
+			p2pkh:        "muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz",
+			p2shP2wpkh:   "2MshDmGtwW3aV8Nr3B3PSvmo5o8yqhwJFKZ",
+			p2wpkh:       "bcrt1qny80vrtrkk6evjsuy2pqvxh52y37j07tghm45z",
+			uncompressed: "mtrNwJxS1VyHYn3qBY1Qfsm3K3kh1mGRMS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.params.Name, func(t *testing.T) {
+			for addrType, want := range map[AddressType]string{
+				AddressTypeP2PKH:      tt.p2pkh,
+				AddressTypeP2SHP2WPKH: tt.p2shP2wpkh,
+				AddressTypeP2WPKH:     tt.p2wpkh,
+			} {
+				got, err := deriveAddress(pubKey, true, addrType, tt.params)
+				if err != nil {
+					t.Fatalf("deriveAddress(%s) error = %v", addrType.String(), err)
+				}
+				if got != want {
+					t.Errorf("deriveAddress(%s) = %s, want %s", addrType.String(), got, want)
+				}
+			}
+
+			got, err := deriveAddress(pubKey, false, AddressTypeP2PKH, tt.params)
+			if err != nil || got != tt.uncompressed {
+				t.Errorf("deriveAddress(uncompressed) = %s, %v; want %s", got, err, tt.uncompressed)
+			}
+
+			got, err = deriveAddressFromPubKey(pubKey, true, tt.params)
+			if err != nil || got != tt.p2pkh {
+				t.Errorf("deriveAddressFromPubKey() = %s, %v; want %s", got, err, tt.p2pkh)
+			}
+
+			got, err = deriveAddressFromPubKey(pubKey, false, tt.params)
+			if err != nil || got != tt.uncompressed {
+				t.Errorf("deriveAddressFromPubKey(uncompressed) = %s, %v; want %s", got, err, tt.uncompressed)
+			}
+		})
+	}
+}
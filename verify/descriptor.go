@@ -0,0 +1,174 @@
+package verify
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// ErrInvalidDescriptor is returned when an output descriptor cannot be parsed
+var ErrInvalidDescriptor = errors.New("invalid output descriptor")
+
+// descriptorFunctions maps the supported descriptor script functions to the
+// address type they produce
+var descriptorFunctions = []struct {
+	prefix   string
+	addrType AddressType
+}{
+	{"sh(wpkh(", AddressTypeP2SHP2WPKH},
+	{"wpkh(", AddressTypeP2WPKH},
+	{"pkh(", AddressTypeP2PKH},
+}
+
+// outputDescriptor is a parsed single-key ranged output descriptor
+type outputDescriptor struct {
+	addrType AddressType
+	origin   string // key origin path without the fingerprint, e.g. "84h/0h/0h"
+	key      *hdkeychain.ExtendedKey
+	path     []uint32 // unhardened steps between the key and the wildcard
+}
+
+// VerifyUnderDescriptor checks whether a BIP-0137 signature was made for one
+// of the first gap addresses of a ranged output descriptor, as exported by
+// hardware wallets, e.g.
+//
+//	wpkh([d34db33f/84h/0h/0h]xpub.../0/*)
+//
+// The pkh, wpkh and sh(wpkh) script functions are supported; a trailing
+// checksum is ignored. The signature's header byte must select the
+// descriptor's address type. On a match the full derivation path of the
+// signing key is returned, including the key origin when one is given, e.g.
+// "84h/0h/0h/0/5".
+func VerifyUnderDescriptor(descriptor, message, signatureBase64 string, gap int, params *chaincfg.Params) (matchedPath string, ok bool, err error) {
+	if gap <= 0 {
+		return "", false, fmt.Errorf("invalid gap limit: %d", gap)
+	}
+
+	desc, err := parseDescriptor(descriptor, params)
+	if err != nil {
+		return "", false, err
+	}
+
+	sigBytes, err := DecodeSignature(signatureBase64)
+	if err != nil {
+		return "", false, err
+	}
+	pubKey, compressed, err := recoverPublicKey(sigBytes, hashBitcoinMessage(message, DefaultVerifyOptions()))
+	if err != nil {
+		return "", false, err
+	}
+	if !headerAllowsAddressType(sigBytes[0], desc.addrType, DefaultVerifyOptions()) {
+		LogDebug("Header byte %d cannot sign for a %s descriptor", sigBytes[0], desc.addrType)
+		return "", false, nil
+	}
+
+	target, err := deriveAddress(pubKey, compressed, desc.addrType, params)
+	if err != nil {
+		return "", false, err
+	}
+
+	parent := desc.key
+	for _, step := range desc.path {
+		if parent, err = parent.Derive(step); err != nil {
+			return "", false, fmt.Errorf("failed to derive descriptor path: %w", err)
+		}
+	}
+
+	for i := 0; i < gap; i++ {
+		child, err := parent.Derive(uint32(i))
+		if err != nil {
+			LogDebug("Skipping invalid child %d: %v", i, err)
+			continue
+		}
+		childKey, err := child.ECPubKey()
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read child key %d: %w", i, err)
+		}
+
+		address, err := deriveAddress(childKey, true, desc.addrType, params)
+		if err != nil {
+			return "", false, err
+		}
+		if address == target {
+			return desc.childPath(uint32(i)), true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// parseDescriptor parses a ranged single-key descriptor such as
+// "wpkh([d34db33f/84h/0h/0h]xpub.../0/*)#checksum"
+func parseDescriptor(s string, params *chaincfg.Params) (*outputDescriptor, error) {
+	if i := strings.IndexByte(s, '#'); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.TrimSpace(s)
+
+	desc := &outputDescriptor{}
+	var inner string
+	for _, fn := range descriptorFunctions {
+		closing := strings.Repeat(")", strings.Count(fn.prefix, "("))
+		if strings.HasPrefix(s, fn.prefix) && strings.HasSuffix(s, closing) {
+			desc.addrType = fn.addrType
+			inner = s[len(fn.prefix) : len(s)-len(closing)]
+			break
+		}
+	}
+	if inner == "" {
+		return nil, fmt.Errorf("%w: unsupported script function in %q (want pkh, wpkh or sh(wpkh))", ErrInvalidDescriptor, s)
+	}
+
+	// Key origin: [fingerprint/path]
+	if strings.HasPrefix(inner, "[") {
+		end := strings.IndexByte(inner, ']')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated key origin", ErrInvalidDescriptor)
+		}
+		fingerprint, origin, _ := strings.Cut(inner[1:end], "/")
+		if b, err := hex.DecodeString(fingerprint); err != nil || len(b) != 4 {
+			return nil, fmt.Errorf("%w: invalid fingerprint %q", ErrInvalidDescriptor, fingerprint)
+		}
+		desc.origin = origin
+		inner = inner[end+1:]
+	}
+
+	parts := strings.Split(inner, "/")
+	if len(parts) < 2 || parts[len(parts)-1] != "*" {
+		return nil, fmt.Errorf("%w: key must end with a /* wildcard", ErrInvalidDescriptor)
+	}
+
+	key, err := parseXpub(parts[0], params)
+	if err != nil {
+		return nil, err
+	}
+	desc.key = key
+
+	for _, step := range parts[1 : len(parts)-1] {
+		index, err := strconv.ParseUint(step, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid or hardened path step %q", ErrInvalidDescriptor, step)
+		}
+		desc.path = append(desc.path, uint32(index))
+	}
+
+	return desc, nil
+}
+
+// childPath returns the full derivation path of the child at index
+func (d *outputDescriptor) childPath(index uint32) string {
+	var steps []string
+	if d.origin != "" {
+		steps = append(steps, d.origin)
+	}
+	for _, step := range d.path {
+		steps = append(steps, strconv.FormatUint(uint64(step), 10))
+	}
+	steps = append(steps, strconv.FormatUint(uint64(index), 10))
+	return strings.Join(steps, "/")
+}
@@ -0,0 +1,114 @@
+package verify
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestVerifyUnderDescriptor(t *testing.T) {
+	message := "Signed by a hardware wallet"
+
+	master, err := hdkeychain.NewMaster(bytes.Repeat([]byte{0x02}, 32), &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("NewMaster() error = %v", err)
+	}
+
+	// Account m/84h/0h/0h and the signing key at 0/3 below it
+	account := master
+	for _, step := range []uint32{84, 0, 0} {
+		if account, err = account.Derive(hdkeychain.HardenedKeyStart + step); err != nil {
+			t.Fatalf("Derive() error = %v", err)
+		}
+	}
+	signer := account
+	for _, step := range []uint32{0, 3} {
+		if signer, err = signer.Derive(step); err != nil {
+			t.Fatalf("Derive() error = %v", err)
+		}
+	}
+	privKey, err := signer.ECPrivKey()
+	if err != nil {
+		t.Fatalf("ECPrivKey() error = %v", err)
+	}
+	xpub, err := account.Neuter()
+	if err != nil {
+		t.Fatalf("Neuter() error = %v", err)
+	}
+
+	// Sign with a P2WPKH header byte (39-42)
+	p2pkhSignature, err := SignBip137Message(privKey, message, true)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+	raw, _ := base64.StdEncoding.DecodeString(p2pkhSignature)
+	raw[0] += 8
+	signature := base64.StdEncoding.EncodeToString(raw)
+
+	tests := []struct {
+		name       string
+		descriptor string
+		signature  string
+		gap        int
+		wantPath   string
+		wantOK     bool
+		wantErr    bool
+	}{
+		{
+			name:       "wpkh with key origin",
+			descriptor: "wpkh([d34db33f/84h/0h/0h]" + xpub.String() + "/0/*)#abcdefgh",
+			signature:  signature,
+			gap:        20,
+			wantPath:   "84h/0h/0h/0/3",
+			wantOK:     true,
+		},
+		{
+			name:       "wpkh beyond the gap limit",
+			descriptor: "wpkh(" + xpub.String() + "/0/*)",
+			signature:  signature,
+			gap:        3,
+		},
+		{
+			name:       "wpkh on the change chain",
+			descriptor: "wpkh(" + xpub.String() + "/1/*)",
+			signature:  signature,
+			gap:        20,
+		},
+		{
+			name:       "pkh with a P2WPKH header byte",
+			descriptor: "pkh(" + xpub.String() + "/0/*)",
+			signature:  signature,
+			gap:        20,
+		},
+		{
+			name:       "pkh",
+			descriptor: "pkh(" + xpub.String() + "/0/*)",
+			signature:  p2pkhSignature,
+			gap:        20,
+			wantPath:   "0/3",
+			wantOK:     true,
+		},
+		{
+			name:       "Unsupported script function",
+			descriptor: "tr(" + xpub.String() + "/0/*)",
+			signature:  signature,
+			gap:        20,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, ok, err := VerifyUnderDescriptor(tt.descriptor, message, tt.signature, tt.gap, &chaincfg.MainNetParams)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyUnderDescriptor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if path != tt.wantPath || ok != tt.wantOK {
+				t.Errorf("VerifyUnderDescriptor() = %q, %v; want %q, %v", path, ok, tt.wantPath, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package verify
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// Diagnosis describes every step of verifying a signature, to explain why a
+// verification failed
+type Diagnosis struct {
+	// Base64OK reports whether the signature is valid base64
+	Base64OK bool
+
+	// Length is the decoded signature length, 65 for a valid signature
+	Length int
+
+	// HeaderByte is the first byte of the decoded signature
+	HeaderByte byte
+
+	// RecoveryID is the recovery ID encoded in the header byte, or -1 if the
+	// header byte is not defined by BIP-0137
+	RecoveryID int
+
+	// Compressed reports whether the header byte selects a compressed key
+	Compressed bool
+
+	// IsLowS reports whether S is in the lower half of the curve order, as
+	// required by rules against signature malleability; see
+	// CanonicalizeSignature
+	IsLowS bool
+
+	// PubKeyHex is the recovered public key in the serialization selected by
+	// the header byte
+	PubKeyHex string
+
+	// DerivedAddresses holds every address derivable from the recovered key
+	DerivedAddresses map[AddressType]string
+
+	// AddressType names the type of the supplied address, or is empty when the
+	// address does not decode for the network
+	AddressType string
+
+	// Matched reports whether any derived address equals the supplied address,
+	// regardless of the address type the header byte selects
+	Matched bool
+
+	// Explanation is a one-line summary of the outcome. It distinguishes a
+	// signature from which no key can be recovered from one that recovers a
+	// key whose addresses differ from the supplied address.
+	Explanation string
+}
+
+// Diagnose runs each step of BIP-0137 verification and records what it found
+// in a Diagnosis. The returned error, if any, describes the step at which the
+// signature could not be processed further; the Diagnosis is filled in up to
+// that step. An address that does not decode is not an error.
+func Diagnose(address, message, signatureBase64 string, params *chaincfg.Params) (Diagnosis, error) {
+	d := Diagnosis{RecoveryID: -1}
+
+	var addr btcutil.Address
+	if decoded, err := btcutil.DecodeAddress(address, params); err == nil && decoded.IsForNet(params) {
+		addr = decoded
+		if addrType, ok := addressTypeOf(addr); ok {
+			d.AddressType = addrType.String()
+		} else {
+			d.AddressType = unsupportedAddressTypeName(addr)
+		}
+	}
+
+	sigBytes, err := base64.StdEncoding.DecodeString(stripWhitespace(signatureBase64))
+	if err != nil {
+		d.Explanation = "signature is not valid base64"
+		return d, fmt.Errorf("invalid base64 signature: %w", err)
+	}
+	d.Base64OK = true
+	d.Length = len(sigBytes)
+
+	if d.Length != CompactSignatureLength {
+		d.Explanation = fmt.Sprintf("signature is %d bytes long, not %d", d.Length, CompactSignatureLength)
+		return d, fmt.Errorf("%w: %d (expected %d bytes)", ErrBadLength, d.Length, CompactSignatureLength)
+	}
+	d.HeaderByte = sigBytes[0]
+
+	if err := validateHeaderByte(d.HeaderByte); err != nil {
+		d.Explanation = fmt.Sprintf("header byte %d is not defined by BIP-0137", d.HeaderByte)
+		return d, err
+	}
+	d.RecoveryID = int(d.HeaderByte-27) & 3
+	d.Compressed = d.HeaderByte >= 31
+
+	var sValue btcec.ModNScalar
+	overflow := sValue.SetByteSlice(sigBytes[33:65])
+	d.IsLowS = !overflow && !sValue.IsZero() && !sValue.IsOverHalfOrder()
+
+	pubKey, compressed, err := recoverPublicKey(sigBytes, hashBitcoinMessage(message, DefaultVerifyOptions()))
+	if err != nil {
+		d.Explanation = "no key recovers from the signature over this message"
+		return d, err
+	}
+	if compressed {
+		d.PubKeyHex = hex.EncodeToString(pubKey.SerializeCompressed())
+	} else {
+		d.PubKeyHex = hex.EncodeToString(pubKey.SerializeUncompressed())
+	}
+
+	d.DerivedAddresses = make(map[AddressType]string)
+	for addrType, derived := range CandidateAddresses(message, signatureBase64, params) {
+		d.DerivedAddresses[addrType] = derived
+		if addr != nil && derived == addr.EncodeAddress() {
+			d.Matched = true
+		}
+	}
+
+	switch {
+	case addr == nil:
+		d.Explanation = fmt.Sprintf("signature recovers key %s, but the supplied address is not valid for %s", d.PubKeyHex, params.Name)
+	case d.Matched:
+		d.Explanation = fmt.Sprintf("signature recovers key %s, whose %s address matches the supplied address", d.PubKeyHex, d.AddressType)
+	default:
+		// Any message yields some key, so a mismatch cannot tell a wrong
+		// message apart from a wrong address
+		d.Explanation = fmt.Sprintf("signature recovers key %s, but no address derived from it matches the supplied address (wrong message, address or signer)", d.PubKeyHex)
+	}
+
+	return d, nil
+}
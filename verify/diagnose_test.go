@@ -0,0 +1,123 @@
+package verify
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestDiagnose(t *testing.T) {
+	// A valid signature checked against the wrong address
+	got, err := Diagnose(testAddress, "Hello, Bitcoin testing!", knownSignature, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("Diagnose() error = %v", err)
+	}
+
+	want := Diagnosis{
+		Base64OK:   true,
+		Length:     65,
+		HeaderByte: 0x20,
+		RecoveryID: 1,
+		Compressed: true,
+		IsLowS:     true,
+		PubKeyHex:  "034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23c",
+		DerivedAddresses: map[AddressType]string{
+			AddressTypeP2PKH:      "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			AddressTypeP2SHP2WPKH: "3Df8mboA4kSahFbXqA8BpSLZfv6V2gnqA8",
+			AddressTypeP2WPKH:     "bc1qtpl26utzhqurdeqhxe7s269hqzte504kqxavae",
+		},
+		AddressType: "P2PKH",
+		Matched:     false,
+		Explanation: "signature recovers key 034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23c, " +
+			"but no address derived from it matches the supplied address (wrong message, address or signer)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diagnose() = %+v, want %+v", got, want)
+	}
+
+	got, err = Diagnose("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", knownSignature, &chaincfg.MainNetParams)
+	if err != nil || !got.Matched {
+		t.Errorf("Diagnose() matched = %v, %v; want true, nil", got.Matched, err)
+	}
+
+	got, err = Diagnose(testAddress, "Hello, Bitcoin testing!", "AAAA", &chaincfg.MainNetParams)
+	if !errors.Is(err, ErrBadLength) || !got.Base64OK || got.Length != 3 || got.RecoveryID != -1 {
+		t.Errorf("Diagnose() = %+v, %v; want a length error", got, err)
+	}
+}
+
+func TestDiagnoseLowS(t *testing.T) {
+	raw, _ := base64.StdEncoding.DecodeString(knownSignature)
+
+	// The high-S twin of knownSignature recovers the same key
+	var s btcec.ModNScalar
+	s.SetByteSlice(raw[33:65])
+	s.Negate()
+	highS := bytes.Clone(raw)
+	s.PutBytesUnchecked(highS[33:65])
+	highS[0] = raw[0] - 1
+
+	tests := []struct {
+		name      string
+		signature string
+		want      bool
+	}{
+		{"Low S", knownSignature, true},
+		{"High S", base64.StdEncoding.EncodeToString(highS), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diagnose("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", tt.signature, &chaincfg.MainNetParams)
+			if err != nil || !got.Matched {
+				t.Fatalf("Diagnose() = %+v, %v; want a match", got, err)
+			}
+			if got.IsLowS != tt.want {
+				t.Errorf("Diagnose() IsLowS = %v, want %v", got.IsLowS, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiagnoseExplanation(t *testing.T) {
+	raw, _ := base64.StdEncoding.DecodeString(knownSignature)
+	zeroR := base64.StdEncoding.EncodeToString(append(append([]byte{raw[0]}, make([]byte, 32)...), raw[33:]...))
+
+	tests := []struct {
+		name      string
+		address   string
+		signature string
+		want      string
+		notWant   string
+	}{
+		{
+			name:      "Key recovered for another address",
+			address:   testAddress,
+			signature: knownSignature,
+			want:      "signature recovers key",
+			notWant:   "no key recovers",
+		},
+		{
+			name:      "No key recovers",
+			address:   "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			signature: zeroR,
+			want:      "no key recovers",
+			notWant:   "signature recovers key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := Diagnose(tt.address, "Hello, Bitcoin testing!", tt.signature, &chaincfg.MainNetParams)
+			if !strings.Contains(got.Explanation, tt.want) || strings.Contains(got.Explanation, tt.notWant) {
+				t.Errorf("Diagnose() explanation = %q, want it to contain %q", got.Explanation, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// envelope is the wire form of a signed JSON envelope. It is kept separate from
+// SignedMessage so that the envelope's field names do not change how
+// SignedMessage itself is encoded.
+type envelope struct {
+	Message   string `json:"message"`
+	Address   string `json:"address"`
+	Signature string `json:"signature"`
+}
+
+// VerifyEnvelope parses a signed JSON envelope of the form
+//
+//	{"message": "...", "address": "...", "signature": "..."}
+//
+// and verifies it with VerifyBip137SignatureWithParams. The parsed message is
+// returned whenever the envelope is well formed, even if verification fails.
+// All three fields are required.
+func VerifyEnvelope(jsonBytes []byte, params *chaincfg.Params) (bool, SignedMessage, error) {
+	var wire envelope
+	if err := json.Unmarshal(jsonBytes, &wire); err != nil {
+		return false, SignedMessage{}, fmt.Errorf("invalid envelope: %w", err)
+	}
+	msg := SignedMessage{Address: wire.Address, Message: wire.Message, Signature: wire.Signature}
+
+	for _, field := range []struct {
+		name  string
+		value string
+		err   error
+	}{
+		{"address", msg.Address, ErrEmptyAddress},
+		{"message", msg.Message, ErrEmptyMessage},
+		{"signature", msg.Signature, ErrEmptySignature},
+	} {
+		if field.value == "" {
+			return false, SignedMessage{}, fmt.Errorf("invalid envelope: missing %q field: %w", field.name, field.err)
+		}
+	}
+
+	valid, err := VerifyBip137SignatureWithParams(msg.Address, msg.Message, msg.Signature, params)
+	return valid, msg, err
+}
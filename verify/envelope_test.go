@@ -0,0 +1,70 @@
+package verify
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestVerifyEnvelope(t *testing.T) {
+	want := SignedMessage{
+		Address:   "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+		Message:   "Hello, Bitcoin testing!",
+		Signature: knownSignature,
+	}
+
+	envelope := `{"message":"Hello, Bitcoin testing!","address":"194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9","signature":"` + knownSignature + `"}`
+	valid, msg, err := VerifyEnvelope([]byte(envelope), &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("VerifyEnvelope() error = %v", err)
+	}
+	if !valid {
+		t.Errorf("VerifyEnvelope() valid = false, want true")
+	}
+	if msg != want {
+		t.Errorf("VerifyEnvelope() message = %+v, want %+v", msg, want)
+	}
+
+	tests := []struct {
+		name     string
+		envelope string
+		wantErr  error
+	}{
+		{
+			name:     "Missing signature",
+			envelope: `{"message":"Hello, Bitcoin testing!","address":"194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9"}`,
+			wantErr:  ErrEmptySignature,
+		},
+		{
+			name:     "Missing address",
+			envelope: `{"message":"Hello, Bitcoin testing!","signature":"` + knownSignature + `"}`,
+			wantErr:  ErrEmptyAddress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := VerifyEnvelope([]byte(tt.envelope), &chaincfg.MainNetParams)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyEnvelope() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, _, err := VerifyEnvelope([]byte(`{"message":`), &chaincfg.MainNetParams); err == nil {
+		t.Errorf("VerifyEnvelope() expected error for malformed JSON")
+	}
+}
+
+func TestSignedMessageJSON(t *testing.T) {
+	// SignedMessage has no JSON tags, so its encoding keeps the Go field names
+	encoded, err := json.Marshal(SignedMessage{Address: "a", Message: "m", Signature: "s"})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"Address":"a","Message":"m","Signature":"s"}`; string(encoded) != want {
+		t.Errorf("json.Marshal() = %s, want %s", encoded, want)
+	}
+}
@@ -26,11 +26,11 @@ func ExampleVerifyBip137Signature() {
 	// Output: Signature valid: true
 }
 
-// ExampleVerifyBip137SignatureWithPubKey demonstrates how to verify a Bitcoin signature
+// ExampleVerifyWithPubKey demonstrates how to verify a Bitcoin signature
 // using a public key directly.
-func ExampleVerifyBip137SignatureWithPubKey() {
+func ExampleVerifyWithPubKey() {
 	// Public key in hex format
-	pubKeyHex := "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+	pubKeyHex := "034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23c"
 	message := "Hello, Bitcoin testing!"
 	signature := "IOeVH/0KqgmS3XKwqCJiwlcHonwxKMQN6fbOW5UsXSDZB4EGCVTXx6c+ZU/Ae5qO94MSBZn2aPOiUsupRIwBaAU="
 
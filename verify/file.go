@@ -0,0 +1,95 @@
+package verify
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// VerifyFile verifies a detached signature as emitted by signing tools that write
+// the message and its signature to separate files (e.g. "message.txt" and
+// "message.txt.sig").
+//
+// The message file is used byte-for-byte: a trailing newline added by an editor
+// is part of the message and must also have been part of the signed content.
+// Verification is as by VerifyBip137SignatureWithParams, so the verifier
+// installed with SetVerifier is used. The signature file may contain
+// surrounding whitespace, including a trailing newline, which is stripped
+// before the base64 signature is decoded.
+func VerifyFile(address, messagePath, signaturePath string, params *chaincfg.Params) (bool, error) {
+	messageBytes, err := os.ReadFile(messagePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read message file: %w", err)
+	}
+
+	signatureBytes, err := os.ReadFile(signaturePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read signature file: %w", err)
+	}
+
+	LogDebug("Read %d message bytes from %s", len(messageBytes), messagePath)
+
+	signature := strings.TrimSpace(string(signatureBytes))
+	return VerifyBip137SignatureWithParams(address, string(messageBytes), signature, params)
+}
+
+// VerifyResult is the outcome of verifying one row of a batch
+type VerifyResult struct {
+	// Line is the 1-based line of the row in its file, or the position of the
+	// message in its batch
+	Line int
+
+	// Message is the signed message read from the row
+	Message SignedMessage
+
+	// Valid reports whether the signature verified
+	Valid bool
+
+	// Err is the error returned by verification, if any
+	Err error
+}
+
+// VerifyCSV verifies every row of a CSV file with the columns address,
+// message and signature, using VerifyBip137SignatureWithParams. Fields follow
+// RFC 4180, so messages containing commas, quotes or newlines must be quoted.
+// A first row naming the columns is skipped.
+//
+// Verification failures are reported per row in the results; the returned
+// error is only set when the file cannot be read or parsed.
+func VerifyCSV(path string, params *chaincfg.Params) ([]VerifyResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV file: %w", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = 3
+
+	var results []VerifyResult
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV file: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if first && strings.EqualFold(record[0], "address") {
+			continue
+		}
+
+		msg := SignedMessage{Address: record[0], Message: record[1], Signature: record[2]}
+		valid, err := VerifyBip137SignatureWithParams(msg.Address, msg.Message, msg.Signature, params)
+		results = append(results, VerifyResult{Line: line, Message: msg, Valid: valid, Err: err})
+	}
+
+	LogDebug("Verified %d rows from %s", len(results), path)
+	return results, nil
+}
@@ -0,0 +1,142 @@
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestVerifyFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		address   string
+		message   string
+		signature string
+		wantValid bool
+		wantErr   bool
+	}{
+		{
+			name:      "Valid detached signature",
+			address:   "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			message:   "Hello, Bitcoin testing!",
+			signature: "IOeVH/0KqgmS3XKwqCJiwlcHonwxKMQN6fbOW5UsXSDZB4EGCVTXx6c+ZU/Ae5qO94MSBZn2aPOiUsupRIwBaAU=\n",
+			wantValid: true,
+			wantErr:   false,
+		},
+		{
+			name:      "Unsigned trailing newline in message file",
+			address:   "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			message:   "Hello, Bitcoin testing!\n",
+			signature: "IOeVH/0KqgmS3XKwqCJiwlcHonwxKMQN6fbOW5UsXSDZB4EGCVTXx6c+ZU/Ae5qO94MSBZn2aPOiUsupRIwBaAU=\n",
+			wantValid: false,
+			wantErr:   false,
+		},
+		{
+			name:      "Tampered message file",
+			address:   "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			message:   "Hello, Bitcoin testing?",
+			signature: "IOeVH/0KqgmS3XKwqCJiwlcHonwxKMQN6fbOW5UsXSDZB4EGCVTXx6c+ZU/Ae5qO94MSBZn2aPOiUsupRIwBaAU=\n",
+			wantValid: false,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			messagePath := filepath.Join(dir, "message.txt")
+			signaturePath := filepath.Join(dir, "message.txt.sig")
+
+			if err := os.WriteFile(messagePath, []byte(tt.message), 0o600); err != nil {
+				t.Fatalf("failed to write message file: %v", err)
+			}
+			if err := os.WriteFile(signaturePath, []byte(tt.signature), 0o600); err != nil {
+				t.Fatalf("failed to write signature file: %v", err)
+			}
+
+			gotValid, err := VerifyFile(tt.address, messagePath, signaturePath, &chaincfg.MainNetParams)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyFile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotValid != tt.wantValid {
+				t.Errorf("VerifyFile() = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+
+	t.Run("Missing message file", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := VerifyFile("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			filepath.Join(dir, "missing.txt"), filepath.Join(dir, "missing.txt.sig"), &chaincfg.MainNetParams)
+		if err == nil {
+			t.Errorf("VerifyFile() expected error for missing files")
+		}
+	})
+
+	t.Run("Installed verifier", func(t *testing.T) {
+		t.Cleanup(func() { SetVerifier(nil) })
+
+		var got SignedMessage
+		SetVerifier(VerifierFunc(func(msg SignedMessage, params *chaincfg.Params) (bool, error) {
+			got = msg
+			return true, nil
+		}))
+
+		dir := t.TempDir()
+		messagePath := filepath.Join(dir, "message.txt")
+		signaturePath := filepath.Join(dir, "message.txt.sig")
+		if err := os.WriteFile(messagePath, []byte("message\n"), 0o600); err != nil {
+			t.Fatalf("failed to write message file: %v", err)
+		}
+		if err := os.WriteFile(signaturePath, []byte("signature\n"), 0o600); err != nil {
+			t.Fatalf("failed to write signature file: %v", err)
+		}
+
+		valid, err := VerifyFile("address", messagePath, signaturePath, &chaincfg.MainNetParams)
+		if err != nil || !valid {
+			t.Fatalf("VerifyFile() = %v, %v; want true, nil", valid, err)
+		}
+		want := SignedMessage{Address: "address", Message: "message\n", Signature: "signature"}
+		if got != want {
+			t.Errorf("VerifyFile() passed %+v to the verifier, want %+v", got, want)
+		}
+	})
+}
+
+func TestVerifyCSV(t *testing.T) {
+	content := `address,message,signature
+194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9,"Hello, Bitcoin testing!",IOeVH/0KqgmS3XKwqCJiwlcHonwxKMQN6fbOW5UsXSDZB4EGCVTXx6c+ZU/Ae5qO94MSBZn2aPOiUsupRIwBaAU=
+1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG,"Hello, Bitcoin testing!",IOeVH/0KqgmS3XKwqCJiwlcHonwxKMQN6fbOW5UsXSDZB4EGCVTXx6c+ZU/Ae5qO94MSBZn2aPOiUsupRIwBaAU=
+`
+	path := filepath.Join(t.TempDir(), "signatures.csv")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write CSV file: %v", err)
+	}
+
+	results, err := VerifyCSV(path, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("VerifyCSV() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("VerifyCSV() returned %d results, want 2", len(results))
+	}
+
+	if got := results[0]; !got.Valid || got.Err != nil || got.Line != 2 || got.Message.Message != "Hello, Bitcoin testing!" {
+		t.Errorf("VerifyCSV() row 1 = %+v, want a valid signature on line 2", got)
+	}
+	if got := results[1]; got.Valid || got.Line != 3 {
+		t.Errorf("VerifyCSV() row 2 = %+v, want an invalid signature on line 3", got)
+	}
+
+	malformed := filepath.Join(t.TempDir(), "malformed.csv")
+	if err := os.WriteFile(malformed, []byte("only,two\n"), 0o600); err != nil {
+		t.Fatalf("failed to write CSV file: %v", err)
+	}
+	if _, err := VerifyCSV(malformed, &chaincfg.MainNetParams); err == nil {
+		t.Errorf("VerifyCSV() expected error for a row with two columns")
+	}
+}
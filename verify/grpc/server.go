@@ -0,0 +1,130 @@
+// Package grpc serves the verify package over gRPC. The service is defined in
+// verify.proto; verify.pb.go and verify_grpc.pb.go are generated from it.
+//
+// A server is registered like any other gRPC service:
+//
+//	s := grpc.NewServer()
+//	verifygrpc.RegisterVerifyServiceServer(s, verifygrpc.NewServer())
+//	s.Serve(listener)
+package grpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative verify.proto
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/cryptopunkscc/bip-0137/verify"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// networks maps the network names accepted in requests to network parameters
+var networks = map[string]*chaincfg.Params{
+	chaincfg.MainNetParams.Name:       &chaincfg.MainNetParams,
+	chaincfg.TestNet3Params.Name:      &chaincfg.TestNet3Params,
+	chaincfg.RegressionNetParams.Name: &chaincfg.RegressionNetParams,
+	chaincfg.SigNetParams.Name:        &chaincfg.SigNetParams,
+}
+
+// Server implements VerifyServiceServer on top of package verify
+type Server struct {
+	UnimplementedVerifyServiceServer
+	opts []verify.Option
+}
+
+// NewServer creates a Server whose verifications are configured by opts, as
+// for verify.VerifyBip137SignatureWithOptions. The network is taken from each
+// request.
+func NewServer(opts ...verify.Option) *Server {
+	return &Server{opts: opts}
+}
+
+// VerifyBip137 implements VerifyServiceServer. Malformed input is reported with
+// codes.InvalidArgument; a well-formed signature that does not match is not an
+// error.
+func (s *Server) VerifyBip137(_ context.Context, req *VerifyRequest) (*VerifyResponse, error) {
+	params, err := network(req.GetNetwork())
+	if err != nil {
+		return nil, err
+	}
+
+	msg := req.GetSignedMessage()
+	valid, err := verify.VerifyBip137SignatureWithOptions(msg.GetAddress(), msg.GetMessage(), msg.GetSignature(), params, s.opts...)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &VerifyResponse{Valid: valid}, nil
+}
+
+// Recover implements VerifyServiceServer
+func (s *Server) Recover(_ context.Context, req *RecoverRequest) (*RecoverResponse, error) {
+	params, err := network(req.GetNetwork())
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, compressed, err := verify.RecoverPubKey(req.GetMessage(), req.GetSignature())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp := &RecoverResponse{Addresses: make(map[string]string)}
+	if compressed {
+		resp.PubKey = hex.EncodeToString(pubKey.SerializeCompressed())
+	} else {
+		resp.PubKey = hex.EncodeToString(pubKey.SerializeUncompressed())
+	}
+	for addrType, address := range verify.CandidateAddresses(req.GetMessage(), req.GetSignature(), params) {
+		resp.Addresses[addrType.String()] = address
+	}
+
+	return resp, nil
+}
+
+// Batch implements VerifyServiceServer. Per-message failures are reported in
+// the results rather than failing the call.
+func (s *Server) Batch(_ context.Context, req *BatchRequest) (*BatchResponse, error) {
+	params, err := network(req.GetNetwork())
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]verify.SignedMessage, len(req.GetSignedMessages()))
+	for i, msg := range req.GetSignedMessages() {
+		msgs[i] = verify.SignedMessage{
+			Address:   msg.GetAddress(),
+			Message:   msg.GetMessage(),
+			Signature: msg.GetSignature(),
+		}
+	}
+
+	opts := append([]verify.Option{}, s.opts...)
+	opts = append(opts, verify.WithParams(params))
+
+	resp := &BatchResponse{}
+	for _, result := range verify.VerifyBatch(msgs, opts...) {
+		out := &BatchResult{Valid: result.Valid}
+		if result.Err != nil {
+			out.Error = result.Err.Error()
+		}
+		resp.Results = append(resp.Results, out)
+	}
+
+	return resp, nil
+}
+
+// network returns the network named in a request, defaulting to mainnet
+func network(name string) (*chaincfg.Params, error) {
+	if name == "" {
+		return &chaincfg.MainNetParams, nil
+	}
+
+	params, ok := networks[name]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown network %q", name)
+	}
+	return params, nil
+}
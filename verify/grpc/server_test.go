@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	knownAddress   = "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9"
+	knownMessage   = "Hello, Bitcoin testing!"
+	knownSignature = "IOeVH/0KqgmS3XKwqCJiwlcHonwxKMQN6fbOW5UsXSDZB4EGCVTXx6c+ZU/Ae5qO94MSBZn2aPOiUsupRIwBaAU="
+)
+
+// newTestClient serves a Server on an in-memory listener and returns a client
+func newTestClient(t *testing.T) VerifyServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	RegisterVerifyServiceServer(server, NewServer())
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return NewVerifyServiceClient(conn)
+}
+
+func TestVerifyBip137(t *testing.T) {
+	client := newTestClient(t)
+
+	tests := []struct {
+		name      string
+		req       *VerifyRequest
+		wantValid bool
+		wantCode  codes.Code
+	}{
+		{
+			name:      "Valid signature",
+			req:       &VerifyRequest{SignedMessage: &SignedMessage{Address: knownAddress, Message: knownMessage, Signature: knownSignature}},
+			wantValid: true,
+			wantCode:  codes.OK,
+		},
+		{
+			name:      "Different message",
+			req:       &VerifyRequest{SignedMessage: &SignedMessage{Address: knownAddress, Message: "Hello, Bitcoin!", Signature: knownSignature}},
+			wantValid: false,
+			wantCode:  codes.OK,
+		},
+		{
+			name:     "Empty address",
+			req:      &VerifyRequest{SignedMessage: &SignedMessage{Message: knownMessage, Signature: knownSignature}},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Unknown network",
+			req:      &VerifyRequest{SignedMessage: &SignedMessage{Address: knownAddress, Message: knownMessage, Signature: knownSignature}, Network: "litecoin"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.VerifyBip137(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("VerifyBip137() code = %v, want %v (error %v)", code, tt.wantCode, err)
+			}
+			if got := resp.GetValid(); got != tt.wantValid {
+				t.Errorf("VerifyBip137() = %v, want %v", got, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestRecoverAndBatch(t *testing.T) {
+	client := newTestClient(t)
+
+	recovered, err := client.Recover(context.Background(), &RecoverRequest{Message: knownMessage, Signature: knownSignature})
+	if err != nil {
+		t.Fatalf("Recover() error = %v", err)
+	}
+	if got := recovered.GetAddresses()["P2PKH"]; got != knownAddress {
+		t.Errorf("Recover() P2PKH address = %s, want %s", got, knownAddress)
+	}
+
+	batch, err := client.Batch(context.Background(), &BatchRequest{SignedMessages: []*SignedMessage{
+		{Address: knownAddress, Message: knownMessage, Signature: knownSignature},
+		{Address: knownAddress, Message: "Hello, Bitcoin!", Signature: knownSignature},
+	}})
+	if err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+	want := []bool{true, false}
+	if len(batch.GetResults()) != len(want) {
+		t.Fatalf("Batch() returned %d results, want %d", len(batch.GetResults()), len(want))
+	}
+	for i, result := range batch.GetResults() {
+		if result.GetValid() != want[i] {
+			t.Errorf("Batch() result %d = %v, want %v", i, result.GetValid(), want[i])
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package verify
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+)
+
+// PreparedMessage returns the exact bytes whose double SHA-256 is signed for
+// message: the compact-size length of the "Bitcoin Signed Message:\n" prefix,
+// the prefix, the compact-size length of the message and the message. It is
+// useful for comparing against other implementations byte for byte.
+func PreparedMessage(message string) []byte {
+	return formatBitcoinMessageForVerification(message)
+}
+
+// MessageHash returns the BIP-0137 digest of message: the double SHA-256 of
+// PreparedMessage(message). It can be passed to VerifyCommitment.
+func MessageHash(message string) [32]byte {
+	var digest [32]byte
+	copy(digest[:], hashBitcoinMessage(message, DefaultVerifyOptions()))
+	return digest
+}
+
+// SigningHash returns the digest that is signed for a message and address type.
+// All BIP-0137 address types sign the double SHA-256 of the prefixed message,
+// so the result is MessageHash(message) for each of them; the address type is
+// taken so that callers, such as SignForAddressType, do not need to encode that
+// policy themselves.
+func SigningHash(message string, addrType AddressType) ([32]byte, error) {
+	switch addrType {
+	case AddressTypeP2PKH, AddressTypeP2SHP2WPKH, AddressTypeP2WPKH:
+		return MessageHash(message), nil
+	default:
+		return [32]byte{}, fmt.Errorf("unsupported address type %d", addrType)
+	}
+}
+
+// hashMessage returns the digest signed for message under opts, decoding the
+// message first when it is Prehashed
+func hashMessage(message string, opts VerifyOptions) ([]byte, error) {
+	if opts.Prehashed {
+		digest, err := hex.DecodeString(message)
+		if err != nil || len(digest) != sha256.Size {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPrehash, message)
+		}
+		message = string(digest)
+	}
+	return hashBitcoinMessage(message, opts), nil
+}
+
+// hashBitcoinMessage formats a message and returns its double SHA-256 digest,
+// or the digest of the InnerHash and OuterHash functions in opts
+func hashBitcoinMessage(message string, opts VerifyOptions) []byte {
+	formatted := formatBitcoinMessage(message, opts)
+	if opts.InnerHash == nil && opts.OuterHash == nil {
+		return doubleSHA256Hash(formatted)
+	}
+
+	inner, outer := opts.InnerHash, opts.OuterHash
+	if inner == nil {
+		inner = sha256Hash
+	}
+	if outer == nil {
+		outer = sha256Hash
+	}
+	return outer(inner(formatted))
+}
+
+// sha256Hash returns the SHA-256 digest of b
+func sha256Hash(b []byte) []byte {
+	sum := sha256.Sum256(b)
+	return sum[:]
+}
+
+// doubleSHA256Hash returns the double SHA-256 digest of b, computed with
+// NewDoubleSHA256
+func doubleSHA256Hash(b []byte) []byte {
+	h := NewDoubleSHA256()
+	h.Write(b)
+	return h.Sum(nil)
+}
+
+// doubleSHA256 is a hash.Hash computing SHA-256(SHA-256(data))
+type doubleSHA256 struct {
+	inner hash.Hash
+}
+
+// NewDoubleSHA256 returns a hash.Hash computing the double SHA-256 used for
+// Bitcoin message digests. Data is written to the inner hash; Sum finalizes it
+// and returns the SHA-256 of the result, without affecting further writes.
+func NewDoubleSHA256() hash.Hash {
+	return &doubleSHA256{inner: sha256.New()}
+}
+
+// Write implements hash.Hash
+func (d *doubleSHA256) Write(p []byte) (int, error) {
+	return d.inner.Write(p)
+}
+
+// Sum implements hash.Hash
+func (d *doubleSHA256) Sum(b []byte) []byte {
+	first := d.inner.Sum(nil)
+	second := sha256.Sum256(first)
+	return append(b, second[:]...)
+}
+
+// Reset implements hash.Hash
+func (d *doubleSHA256) Reset() {
+	d.inner.Reset()
+}
+
+// Size implements hash.Hash
+func (d *doubleSHA256) Size() int {
+	return sha256.Size
+}
+
+// BlockSize implements hash.Hash
+func (d *doubleSHA256) BlockSize() int {
+	return sha256.BlockSize
+}
@@ -0,0 +1,130 @@
+package verify
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestSigningHash(t *testing.T) {
+	message := "Hello, Bitcoin testing!"
+
+	first := sha256.Sum256(formatBitcoinMessageForVerification(message))
+	want := sha256.Sum256(first[:])
+
+	for _, addrType := range []AddressType{AddressTypeP2PKH, AddressTypeP2SHP2WPKH, AddressTypeP2WPKH} {
+		got, err := SigningHash(message, addrType)
+		if err != nil {
+			t.Errorf("SigningHash(%d) error = %v", addrType, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("SigningHash(%d) = %x, want %x", addrType, got, want)
+		}
+	}
+
+	if _, err := SigningHash(message, AddressType(99)); err == nil {
+		t.Errorf("SigningHash() expected error for unsupported address type")
+	}
+}
+
+func TestPreparedMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			name:    "Known message",
+			message: "Hello, Bitcoin testing!",
+			want:    "18426974636f696e205369676e6564204d6573736167653a0a1748656c6c6f2c20426974636f696e2074657374696e6721",
+		},
+		{
+			name:    "Empty message",
+			message: "",
+			want:    "18426974636f696e205369676e6564204d6573736167653a0a00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hex.EncodeToString(PreparedMessage(tt.message)); got != tt.want {
+				t.Errorf("PreparedMessage() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDoubleSHA256(t *testing.T) {
+	inputs := [][]byte{
+		nil,
+		[]byte("abc"),
+		formatBitcoinMessageForVerification("Hello, Bitcoin testing!"),
+		bytes.Repeat([]byte{0x5a}, 1000),
+	}
+
+	h := NewDoubleSHA256()
+	if h.Size() != 32 || h.BlockSize() != 64 {
+		t.Errorf("Size(), BlockSize() = %d, %d; want 32, 64", h.Size(), h.BlockSize())
+	}
+
+	for _, input := range inputs {
+		first := sha256.Sum256(input)
+		want := sha256.Sum256(first[:])
+
+		h.Reset()
+		// Write in two parts to exercise streaming
+		h.Write(input[:len(input)/2])
+		h.Write(input[len(input)/2:])
+
+		if got := h.Sum(nil); !bytes.Equal(got, want[:]) {
+			t.Errorf("Sum() for %d bytes = %x, want %x", len(input), got, want)
+		}
+
+		// Sum appends and does not change the state
+		if got := h.Sum([]byte{0x01}); !bytes.Equal(got, append([]byte{0x01}, want[:]...)) {
+			t.Errorf("Sum(prefix) for %d bytes = %x", len(input), got)
+		}
+	}
+}
+
+func TestInnerOuterHash(t *testing.T) {
+	const message = "Hello, Bitcoin testing!"
+	calls := 0
+	singleSHA256 := func(b []byte) []byte {
+		calls++
+		sum := sha256.Sum256(b)
+		return sum[:]
+	}
+
+	// Two injected single SHA-256 passes reproduce the double SHA-256
+	want := MessageHash(message)
+	got := hashBitcoinMessage(message, newVerifyOptions(WithInnerHash(singleSHA256), WithOuterHash(singleSHA256)))
+	if !bytes.Equal(got, want[:]) || calls != 2 {
+		t.Errorf("hashBitcoinMessage() = %x after %d calls, want %x after 2", got, calls, want)
+	}
+
+	valid, err := VerifyBip137SignatureWithOptions("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", message, knownSignature,
+		&chaincfg.MainNetParams, WithInnerHash(singleSHA256), WithOuterHash(singleSHA256))
+	if err != nil || !valid {
+		t.Errorf("VerifyBip137SignatureWithOptions() = %v, %v, want true, nil", valid, err)
+	}
+
+	// A signature over the single SHA-256 only verifies with an identity outer hash
+	single := sha256.Sum256(PreparedMessage(message))
+	sig := base64.StdEncoding.EncodeToString(ecdsa.SignCompact(testPrivKey(t), single[:], true))
+	identity := func(b []byte) []byte { return b }
+
+	if valid, _ := VerifyBip137SignatureWithOptions(testAddress, message, sig, &chaincfg.MainNetParams); valid {
+		t.Errorf("VerifyBip137SignatureWithOptions() = true for a single-hash signature, want false")
+	}
+	valid, err = VerifyBip137SignatureWithOptions(testAddress, message, sig, &chaincfg.MainNetParams, WithOuterHash(identity))
+	if err != nil || !valid {
+		t.Errorf("VerifyBip137SignatureWithOptions() with identity outer hash = %v, %v, want true, nil", valid, err)
+	}
+}
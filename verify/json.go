@@ -0,0 +1,51 @@
+package verify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// CanonicalJSON encodes v as deterministic JSON, so structured data can be
+// signed as a message: object keys are sorted, there is no insignificant
+// whitespace and HTML characters are not escaped. Numbers keep the text
+// produced by encoding/json. Values that encode to the same JSON objects, such
+// as a map and a struct with different field order, give the same result.
+func CanonicalJSON(v any) (string, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	// Decode into generic values, whose maps encoding/json writes sorted
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return "", fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(generic); err != nil {
+		return "", fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	// Encode terminates the value with a newline
+	return string(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
+
+// VerifyJSON verifies a signature over the CanonicalJSON encoding of v with
+// VerifyBip137SignatureWithParams
+func VerifyJSON(address string, v any, signatureBase64 string, params *chaincfg.Params) (bool, error) {
+	message, err := CanonicalJSON(v)
+	if err != nil {
+		return false, err
+	}
+
+	LogDebug("Verifying canonical JSON message of %d bytes", len(message))
+	return VerifyBip137SignatureWithParams(address, message, signatureBase64, params)
+}
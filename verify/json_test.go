@@ -0,0 +1,72 @@
+package verify
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"Sorted keys", map[string]any{"b": 1, "a": []int{2, 3}}, `{"a":[2,3],"b":1}`},
+		{"Nested struct", struct {
+			Z string         `json:"z"`
+			A map[string]int `json:"a"`
+		}{"<&>", map[string]int{"y": 1, "x": 2}}, `{"a":{"x":2,"y":1},"z":"<&>"}`},
+		{"Large number", map[string]any{"n": json.Number("12345678901234567890")}, `{"n":12345678901234567890}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalJSON(tt.value)
+			if err != nil {
+				t.Fatalf("CanonicalJSON() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CanonicalJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyJSON(t *testing.T) {
+	signed := map[string]any{"amount": 100, "to": "Alice", "memo": "rent"}
+	message, err := CanonicalJSON(signed)
+	if err != nil {
+		t.Fatalf("CanonicalJSON() error = %v", err)
+	}
+	signature, err := SignBip137Message(testPrivKey(t), message, true)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+
+	// The same object with a different key order, as received over the wire
+	var received map[string]any
+	if err := json.Unmarshal([]byte(`{"to": "Alice", "memo": "rent", "amount": 100}`), &received); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		value     any
+		wantValid bool
+	}{
+		{"Signed object", signed, true},
+		{"Reordered object", received, true},
+		{"Changed object", map[string]any{"amount": 1000, "to": "Alice", "memo": "rent"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, _ := VerifyJSON(testAddress, tt.value, signature, &chaincfg.MainNetParams)
+			if gotValid != tt.wantValid {
+				t.Errorf("VerifyJSON() = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+}
@@ -1,10 +1,15 @@
 package verify
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"os"
 	"strings"
+	"sync/atomic"
 )
 
 // LogLevel determines the verbosity of logging
@@ -18,52 +23,158 @@ const (
 	LogLevelTrace
 )
 
+// logLevelNames holds the name of each log level, as used by ParseLogLevel
+var logLevelNames = map[LogLevel]string{
+	LogLevelNone:  "none",
+	LogLevelError: "error",
+	LogLevelInfo:  "info",
+	LogLevelDebug: "debug",
+	LogLevelTrace: "trace",
+}
+
+// ParseLogLevel returns the log level with the given name ("none", "error",
+// "info", "debug" or "trace"), ignoring case
+func ParseLogLevel(name string) (LogLevel, error) {
+	for level, levelName := range logLevelNames {
+		if strings.EqualFold(name, levelName) {
+			return level, nil
+		}
+	}
+	return LogLevelNone, fmt.Errorf("unknown log level %q", name)
+}
+
+// String returns the name of the log level
+func (l LogLevel) String() string {
+	if name, ok := logLevelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("LogLevel(%d)", int(l))
+}
+
+// MarshalText implements encoding.TextMarshaler, so log levels appear by name
+// in JSON and YAML configuration
+func (l LogLevel) MarshalText() ([]byte, error) {
+	name, ok := logLevelNames[l]
+	if !ok {
+		return nil, fmt.Errorf("unknown log level %d", int(l))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseLogLevel
+func (l *LogLevel) UnmarshalText(text []byte) error {
+	level, err := ParseLogLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
 var (
-	// Current log level, default to info
-	currentLogLevel = LogLevelInfo
+	// Current log level, default to info. It is accessed atomically so that
+	// it can be changed while verifications run on other goroutines.
+	currentLogLevel atomic.Int32
 
 	// Logger instance
 	Logger = log.New(os.Stdout, "", log.LstdFlags)
 )
 
+func init() {
+	currentLogLevel.Store(int32(LogLevelInfo))
+}
+
 // SetLogLevel sets the current logging level
 func SetLogLevel(level LogLevel) {
-	currentLogLevel = level
+	currentLogLevel.Store(int32(level))
+}
+
+// Whether message contents and signature bytes are redacted in log output
+var redactLogs atomic.Bool
+
+// SetRedactLogs sets whether log output redacts message contents and signature
+// bytes. Redacted values are logged as their length and a short SHA-256
+// prefix, which is enough to tell values apart without revealing them.
+func SetRedactLogs(redact bool) {
+	redactLogs.Store(redact)
+}
+
+// redacted formats data as hex for logging, or as a digest under SetRedactLogs
+func redacted(data []byte) string {
+	if !redactLogs.Load() {
+		return fmt.Sprintf("%x", data)
+	}
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("<redacted %d bytes, sha256 %x>", len(data), sum[:4])
 }
 
 // GetLogLevel returns the current logging level
 func GetLogLevel() LogLevel {
-	return currentLogLevel
+	return LogLevel(currentLogLevel.Load())
 }
 
 // LogError logs an error message
 func LogError(format string, args ...interface{}) {
-	if currentLogLevel >= LogLevelError {
+	if GetLogLevel() >= LogLevelError {
 		Logger.Printf("[ERROR] "+format, args...)
 	}
 }
 
 // LogInfo logs an info message
 func LogInfo(format string, args ...interface{}) {
-	if currentLogLevel >= LogLevelInfo {
+	if GetLogLevel() >= LogLevelInfo {
 		Logger.Printf("[INFO] "+format, args...)
 	}
 }
 
 // LogDebug logs a debug message
 func LogDebug(format string, args ...interface{}) {
-	if currentLogLevel >= LogLevelDebug {
+	if GetLogLevel() >= LogLevelDebug {
 		Logger.Printf("[DEBUG] "+format, args...)
 	}
 }
 
 // LogTrace logs a trace message (most detailed)
 func LogTrace(format string, args ...interface{}) {
-	if currentLogLevel >= LogLevelTrace {
+	if GetLogLevel() >= LogLevelTrace {
 		Logger.Printf("[TRACE] "+format, args...)
 	}
 }
 
+// requestIDKey is the context key of a verification's request ID
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying a request ID, which
+// VerifyBip137SignatureWithContext includes in every log line of the call so
+// that concurrent verifications can be told apart. Without one, a random ID is
+// generated for each call.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, if any
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// newRequestID returns a random 16-character hex request ID
+func newRequestID() string {
+	var b [8]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// logContext logs through logf (LogInfo, LogDebug, ...) with the request ID
+// carried by ctx, if any, before the message
+func logContext(ctx context.Context, logf func(string, ...interface{}), format string, args ...interface{}) {
+	if id, ok := RequestIDFromContext(ctx); ok {
+		logf("[req=%s] "+format, append([]interface{}{id}, args...)...)
+		return
+	}
+	logf(format, args...)
+}
+
 // DumpHex returns a hexadecimal representation of the data
 func DumpHex(data []byte) string {
 	if len(data) == 0 {
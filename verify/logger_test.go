@@ -0,0 +1,145 @@
+package verify
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLogLevelJSON(t *testing.T) {
+	tests := []struct {
+		level LogLevel
+		json  string
+	}{
+		{LogLevelNone, `"none"`},
+		{LogLevelError, `"error"`},
+		{LogLevelInfo, `"info"`},
+		{LogLevelDebug, `"debug"`},
+		{LogLevelTrace, `"trace"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			data, err := json.Marshal(tt.level)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(data) != tt.json {
+				t.Errorf("json.Marshal() = %s, want %s", data, tt.json)
+			}
+
+			var got LogLevel
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if got != tt.level {
+				t.Errorf("json.Unmarshal() = %v, want %v", got, tt.level)
+			}
+		})
+	}
+
+	var level LogLevel
+	if err := json.Unmarshal([]byte(`"DEBUG"`), &level); err != nil || level != LogLevelDebug {
+		t.Errorf("json.Unmarshal(\"DEBUG\") = %v, %v; want debug, nil", level, err)
+	}
+	if err := json.Unmarshal([]byte(`"verbose"`), &level); err == nil {
+		t.Errorf("json.Unmarshal(\"verbose\") expected error")
+	}
+	if _, err := json.Marshal(LogLevel(42)); err == nil {
+		t.Errorf("json.Marshal(LogLevel(42)) expected error")
+	}
+}
+
+func TestSetRedactLogs(t *testing.T) {
+	t.Cleanup(func() {
+		Configure(Config{LogLevel: LogLevelInfo, LogOutput: os.Stdout})
+	})
+
+	const message = "Confidential: the vault code is 4711"
+	pubKeyHex := "034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23c"
+
+	tests := []struct {
+		name       string
+		redact     bool
+		wantSecret bool
+	}{
+		{"Redacted", true, false},
+		{"Not redacted", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			Configure(Config{LogLevel: LogLevelTrace, LogOutput: &buf, RedactLogs: tt.redact})
+
+			VerifyWithPubKeyHex(pubKeyHex, message, knownSignature)
+
+			output := buf.String()
+			gotSecret := strings.Contains(output, hex.EncodeToString([]byte(message)))
+			if gotSecret != tt.wantSecret {
+				t.Errorf("log output contains the message = %v, want %v:\n%s", gotSecret, tt.wantSecret, output)
+			}
+			if got := strings.Contains(output, "<redacted"); got != tt.redact {
+				t.Errorf("log output contains redacted values = %v, want %v", got, tt.redact)
+			}
+		})
+	}
+}
+
+func TestContextRequestID(t *testing.T) {
+	t.Cleanup(func() {
+		Configure(Config{LogLevel: LogLevelInfo, LogOutput: os.Stdout})
+	})
+
+	var buf bytes.Buffer
+	Configure(Config{LogLevel: LogLevelDebug, LogOutput: &buf})
+
+	msg := SignedMessage{Address: "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", Message: "Hello, Bitcoin testing!", Signature: knownSignature}
+	var wg sync.WaitGroup
+	for _, id := range []string{"req-a", "req-b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := ContextWithRequestID(context.Background(), id)
+			if valid, err := VerifyBip137SignatureWithContext(ctx, msg); err != nil || !valid {
+				t.Errorf("VerifyBip137SignatureWithContext() = %v, %v, want true, nil", valid, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	counts := map[string]int{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		switch {
+		case strings.Contains(line, "[req=req-a]"):
+			counts["req-a"]++
+		case strings.Contains(line, "[req=req-b]"):
+			counts["req-b"]++
+		default:
+			t.Errorf("log line without a request ID: %q", line)
+		}
+	}
+	if counts["req-a"] == 0 || counts["req-a"] != counts["req-b"] {
+		t.Errorf("log lines per request ID = %v, want the same non-zero count for each", counts)
+	}
+
+	// Without a caller-supplied ID, each call generates its own
+	buf.Reset()
+	VerifyBip137SignatureWithContext(context.Background(), msg)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	_, rest, ok := strings.Cut(lines[0], "[req=")
+	id, _, _ := strings.Cut(rest, "]")
+	if !ok || len(id) != 16 {
+		t.Fatalf("log line %q does not carry a generated request ID", lines[0])
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, "[req="+id+"]") {
+			t.Errorf("log line %q does not carry request ID %s", line, id)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package verify
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+var (
+	networksMu     sync.RWMutex
+	customNetworks []*chaincfg.Params
+)
+
+// anyNetworks lists the networks tried by VerifyAnyNetwork, in order
+var anyNetworks = []*chaincfg.Params{
+	&chaincfg.MainNetParams,
+	&chaincfg.TestNet3Params,
+	&chaincfg.SigNetParams,
+}
+
+// VerifyAnyNetwork verifies a signature for an address whose network is not
+// known. It tries mainnet, testnet3 and signet with
+// VerifyBip137SignatureWithParams and returns the name of the first network
+// (as in chaincfg.Params.Name) under which the signature is valid. Testnet3
+// and signet share address encodings, so such addresses report "testnet3".
+//
+// If the address is valid on none of the networks the error wraps
+// ErrInvalidAddress; otherwise the error of the last network tried is returned
+// when no network verifies.
+func VerifyAnyNetwork(address, message, signatureBase64 string) (bool, string, error) {
+	var lastErr error
+	for _, params := range anyNetworks {
+		if err := ValidateAddress(address, params); err != nil {
+			continue
+		}
+
+		valid, err := VerifyBip137SignatureWithParams(address, message, signatureBase64, params)
+		if valid {
+			return true, params.Name, nil
+		}
+		lastErr = err
+	}
+
+	if lastErr == nil {
+		var err error
+		if address == "" {
+			err = ErrEmptyAddress
+		} else {
+			err = fmt.Errorf("%w: %s is not valid on any known network", ErrInvalidAddress, address)
+		}
+		return false, "", err
+	}
+	return false, "", lastErr
+}
+
+// RegisterNetwork registers custom network parameters with chaincfg, which
+// bech32 decoding requires, and adds them to the networks PossibleNetworks
+// considers. Registering the same params again has no effect; other params
+// with the Net of a registered network fail with chaincfg.ErrDuplicateNet.
+func RegisterNetwork(params *chaincfg.Params) error {
+	networksMu.Lock()
+	defer networksMu.Unlock()
+
+	if slices.Contains(customNetworks, params) {
+		return nil
+	}
+	if err := chaincfg.Register(params); err != nil {
+		return fmt.Errorf("could not register network %s: %w", params.Name, err)
+	}
+	customNetworks = append(customNetworks, params)
+	return nil
+}
+
+// PossibleNetworks returns the names (as in chaincfg.Params.Name) of every
+// network on which address decodes: mainnet, testnet3, regtest and signet, in
+// that order, followed by networks added with RegisterNetwork. Networks that
+// share address encodings, such as testnet3 and signet, are all returned. If
+// the address decodes on none of them the error wraps ErrInvalidAddress.
+func PossibleNetworks(address string) ([]string, error) {
+	if address == "" {
+		return nil, ErrEmptyAddress
+	}
+
+	networksMu.RLock()
+	networks := slices.Concat(knownNetworks, customNetworks)
+	networksMu.RUnlock()
+
+	var names []string
+	for _, params := range networks {
+		if addr, err := btcutil.DecodeAddress(address, params); err == nil && addr.IsForNet(params) {
+			names = append(names, params.Name)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: %s is not valid on any known network", ErrInvalidAddress, address)
+	}
+	return names, nil
+}
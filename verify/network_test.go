@@ -0,0 +1,114 @@
+package verify
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestVerifyAnyNetwork(t *testing.T) {
+	tests := []struct {
+		name        string
+		address     string
+		message     string
+		signature   string
+		wantValid   bool
+		wantNetwork string
+		wantErr     error
+	}{
+		{
+			name:        "Mainnet address",
+			address:     "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			message:     "Hello, Bitcoin testing!",
+			signature:   knownSignature,
+			wantValid:   true,
+			wantNetwork: "mainnet",
+		},
+		{
+			name:        "Testnet address",
+			address:     "muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz",
+			message:     "Hello, Bitcoin testnet!",
+			signature:   "H1iW5hS8TmYTjWSKywZrcYRfPiXT/LhoY+uqCYa64XqTP1oFtFTALrfpB1giJ3ks5z6Eax9xVogwNrlOnR5s2SI=",
+			wantValid:   true,
+			wantNetwork: "testnet3",
+		},
+		{
+			name:      "Unknown address",
+			address:   "not-an-address",
+			message:   "Hello, Bitcoin testing!",
+			signature: knownSignature,
+			wantErr:   ErrInvalidAddress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, gotNetwork, err := VerifyAnyNetwork(tt.address, tt.message, tt.signature)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyAnyNetwork() error = %v, want %v", err, tt.wantErr)
+			}
+			if gotValid != tt.wantValid || gotNetwork != tt.wantNetwork {
+				t.Errorf("VerifyAnyNetwork() = %v, %q, want %v, %q", gotValid, gotNetwork, tt.wantValid, tt.wantNetwork)
+			}
+		})
+	}
+}
+
+// customNetworkA and customNetworkB share address encodings, as forks of one
+// chain might
+var customNetworkA, customNetworkB = func() (*chaincfg.Params, *chaincfg.Params) {
+	a, b := chaincfg.MainNetParams, chaincfg.MainNetParams
+	a.Name, a.Net = "custom-a", 0xfeedbeef
+	b.Name, b.Net = "custom-b", 0xfeedbef0
+	for _, params := range []*chaincfg.Params{&a, &b} {
+		params.PubKeyHashAddrID = 0x1e
+		params.Bech32HRPSegwit = "cst"
+	}
+	return &a, &b
+}()
+
+func TestPossibleNetworks(t *testing.T) {
+	for _, params := range []*chaincfg.Params{customNetworkA, customNetworkB} {
+		if err := RegisterNetwork(params); err != nil {
+			t.Fatalf("RegisterNetwork() error = %v", err)
+		}
+	}
+
+	hash := btcutil.Hash160(testPrivKey(t).PubKey().SerializeCompressed())
+	customP2PKH, _ := btcutil.NewAddressPubKeyHash(hash, customNetworkA)
+	customP2WPKH, _ := btcutil.NewAddressWitnessPubKeyHash(hash, customNetworkA)
+
+	tests := []struct {
+		name    string
+		address string
+		want    []string
+		wantErr error
+	}{
+		{"Mainnet only", testAddress, []string{"mainnet"}, nil},
+		{"Shared testnet encoding", "muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz", []string{"testnet3", "regtest", "signet"}, nil},
+		{"Custom P2PKH", customP2PKH.EncodeAddress(), []string{"custom-a", "custom-b"}, nil},
+		{"Custom P2WPKH", customP2WPKH.EncodeAddress(), []string{"custom-a", "custom-b"}, nil},
+		{"Invalid", "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UH", nil, ErrInvalidAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PossibleNetworks(tt.address)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("PossibleNetworks() error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("PossibleNetworks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// The Net of a registered network cannot be reused
+	duplicate := *customNetworkA
+	if err := RegisterNetwork(&duplicate); !errors.Is(err, chaincfg.ErrDuplicateNet) {
+		t.Errorf("RegisterNetwork() error = %v, want chaincfg.ErrDuplicateNet", err)
+	}
+}
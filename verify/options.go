@@ -0,0 +1,298 @@
+package verify
+
+import (
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// DefaultPrefixSeparator is the separator that follows the message magic in the
+// standard "Bitcoin Signed Message:\n" prefix.
+const DefaultPrefixSeparator = "\n"
+
+// messageMagic is the message magic without its trailing separator
+const messageMagic = "Bitcoin Signed Message:"
+
+// VerifyOptions controls how messages are formatted and signatures are checked
+// by the option-aware verification functions.
+type VerifyOptions struct {
+	// Params selects the network used by a Client. Functions that take the
+	// network parameters as an argument ignore it. Defaults to mainnet.
+	Params *chaincfg.Params
+
+	// Verifier replaces the built-in verifier used by a Client
+	Verifier Verifier
+
+	// PrefixSeparator is appended to the message magic before it is serialized.
+	// It defaults to "\n"; some legacy signers omitted it or used another value.
+	PrefixSeparator string
+
+	// MessagePrefixBytes, when not nil, replaces the whole message prefix
+	// (magic and separator) with arbitrary bytes, for forks whose magic is not
+	// ASCII. PrefixSeparator is ignored when it is set.
+	MessagePrefixBytes []byte
+
+	// LedgerCompat accepts the compressed P2PKH header bytes (31-34) for
+	// P2SH-P2WPKH and P2WPKH addresses. Ledger's Bitcoin app (like Electrum)
+	// does not use the BIP-0137 segwit header ranges when signing for a
+	// segwit address, so its signatures only verify with this enabled.
+	LedgerCompat bool
+
+	// LenientHeader accepts header bytes 0-3, emitted by some older secp256k1
+	// bindings, as a raw recovery ID for a compressed key. Such a header does
+	// not encode an address type, so it is accepted for any supported type.
+	LenientHeader bool
+
+	// LenientLength accepts signatures that decode to more than 65 bytes, as
+	// some tools append extra bytes, by using only the first 65 and logging a
+	// warning. Otherwise such signatures fail with ErrBadLength.
+	LenientLength bool
+
+	// TrailingNewline appends "\n" to the message before it is formatted, as
+	// some web wallets do when signing
+	TrailingNewline bool
+
+	// Prehashed is for protocols that sign the SHA-256 digest of a document
+	// rather than the document itself. The message is then the hex encoding of
+	// that 32-byte digest; the decoded digest bytes are the signed message,
+	// wrapped with the prefix and double hashed as usual. The digest is not
+	// treated as the final signed hash.
+	Prehashed bool
+
+	// InnerHash and OuterHash replace the two SHA-256 passes applied to the
+	// formatted message, for cross-checking other implementations or testing
+	// single-hash and alternate schemes. A nil function is SHA-256; the
+	// signed digest is OuterHash(InnerHash(formatted message)).
+	InnerHash func([]byte) []byte
+	OuterHash func([]byte) []byte
+
+	// StrictCompression turns the warning logged when the header byte's
+	// compression flag contradicts the address into ErrCompressionMismatch
+	StrictCompression bool
+
+	// Allowlist, when not empty, restricts verification to these addresses.
+	// Other addresses fail with ErrAddressNotAllowed, even if the signature is
+	// valid. Addresses are compared in their canonical encoding.
+	Allowlist []string
+
+	// Blocklist lists addresses whose signatures fail with
+	// ErrAddressNotAllowed, even if they are valid
+	Blocklist []string
+
+	// StopOnFirstMatch stops AddressSet.Verify at the first address type that
+	// matches the signer. Disable it to find every address of the signer in
+	// the set. Defaults to true.
+	StopOnFirstMatch bool
+
+	// PreferType is the address type AddressSet.Verify tries first when set
+	// with WithPreferType. By default the type selected by the signature's
+	// header byte is tried first.
+	PreferType AddressType
+
+	// preferTypeSet records whether PreferType was set
+	preferTypeSet bool
+
+	// Concurrency is the number of workers used by VerifyBatch and
+	// VerifyStream. Zero means runtime.GOMAXPROCS(0).
+	Concurrency int
+}
+
+// Option configures VerifyOptions
+type Option func(*VerifyOptions)
+
+// DefaultVerifyOptions returns the options used when no Option is supplied
+func DefaultVerifyOptions() VerifyOptions {
+	return VerifyOptions{
+		Params:           &chaincfg.MainNetParams,
+		PrefixSeparator:  DefaultPrefixSeparator,
+		StopOnFirstMatch: true,
+	}
+}
+
+// WithParams selects the network used by a Client
+func WithParams(params *chaincfg.Params) Option {
+	return func(o *VerifyOptions) {
+		o.Params = params
+	}
+}
+
+// WithVerifier sets the verifier used by a Client
+func WithVerifier(verifier Verifier) Option {
+	return func(o *VerifyOptions) {
+		o.Verifier = verifier
+	}
+}
+
+// WithPrefixSeparator overrides the separator that follows the message magic.
+// Pass an empty string to match signers that omitted the newline.
+func WithPrefixSeparator(separator string) Option {
+	return func(o *VerifyOptions) {
+		o.PrefixSeparator = separator
+	}
+}
+
+// WithMessagePrefix replaces the whole message prefix, e.g. with
+// "Litecoin Signed Message:\n". It is a convenience for WithMessagePrefixBytes.
+func WithMessagePrefix(prefix string) Option {
+	return WithMessagePrefixBytes([]byte(prefix))
+}
+
+// WithMessagePrefixBytes replaces the whole message prefix with raw bytes
+func WithMessagePrefixBytes(prefix []byte) Option {
+	return func(o *VerifyOptions) {
+		o.MessagePrefixBytes = append([]byte{}, prefix...)
+	}
+}
+
+// WithLedgerCompat enables LedgerCompat
+func WithLedgerCompat() Option {
+	return func(o *VerifyOptions) {
+		o.LedgerCompat = true
+	}
+}
+
+// WithElectrumCompat accepts signatures made by Electrum, which like Ledger
+// uses the compressed P2PKH header bytes (31-34) for every address type. It
+// enables LedgerCompat.
+func WithElectrumCompat() Option {
+	return WithLedgerCompat()
+}
+
+// WithLenientHeader enables LenientHeader
+func WithLenientHeader() Option {
+	return func(o *VerifyOptions) {
+		o.LenientHeader = true
+	}
+}
+
+// WithLenientLength enables LenientLength
+func WithLenientLength() Option {
+	return func(o *VerifyOptions) {
+		o.LenientLength = true
+	}
+}
+
+// WithTrailingNewline enables TrailingNewline
+func WithTrailingNewline() Option {
+	return func(o *VerifyOptions) {
+		o.TrailingNewline = true
+	}
+}
+
+// WithPrehashed enables Prehashed
+func WithPrehashed() Option {
+	return func(o *VerifyOptions) {
+		o.Prehashed = true
+	}
+}
+
+// WithInnerHash sets InnerHash
+func WithInnerHash(hash func([]byte) []byte) Option {
+	return func(o *VerifyOptions) {
+		o.InnerHash = hash
+	}
+}
+
+// WithOuterHash sets OuterHash
+func WithOuterHash(hash func([]byte) []byte) Option {
+	return func(o *VerifyOptions) {
+		o.OuterHash = hash
+	}
+}
+
+// WithStrictCompression enables StrictCompression
+func WithStrictCompression() Option {
+	return func(o *VerifyOptions) {
+		o.StrictCompression = true
+	}
+}
+
+// WithAllowlist adds addresses to Allowlist
+func WithAllowlist(addresses ...string) Option {
+	return func(o *VerifyOptions) {
+		o.Allowlist = append(o.Allowlist, addresses...)
+	}
+}
+
+// WithBlocklist adds addresses to Blocklist
+func WithBlocklist(addresses ...string) Option {
+	return func(o *VerifyOptions) {
+		o.Blocklist = append(o.Blocklist, addresses...)
+	}
+}
+
+// WithStopOnFirstMatch sets StopOnFirstMatch
+func WithStopOnFirstMatch(stop bool) Option {
+	return func(o *VerifyOptions) {
+		o.StopOnFirstMatch = stop
+	}
+}
+
+// WithPreferType sets PreferType
+func WithPreferType(addrType AddressType) Option {
+	return func(o *VerifyOptions) {
+		o.PreferType = addrType
+		o.preferTypeSet = true
+	}
+}
+
+// WithConcurrency bounds the number of messages VerifyBatch and VerifyStream
+// verify at once. Zero and negative values of n are not rejected: they are
+// clamped to zero, so VerifyBatch and VerifyStream fall back to
+// runtime.GOMAXPROCS(0) workers.
+func WithConcurrency(n int) Option {
+	return func(o *VerifyOptions) {
+		o.Concurrency = max(n, 0)
+	}
+}
+
+// newVerifyOptions applies opts on top of the default options
+func newVerifyOptions(opts ...Option) VerifyOptions {
+	options := DefaultVerifyOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// workers returns the number of workers for batch verification
+func (o VerifyOptions) workers() int {
+	if o.Concurrency > 0 {
+		return o.Concurrency
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// addressAllowed reports whether the Allowlist and Blocklist permit address,
+// given in its canonical encoding for params
+func (o VerifyOptions) addressAllowed(address string, params *chaincfg.Params) bool {
+	if slices.ContainsFunc(o.Blocklist, func(listed string) bool {
+		return normalizeAddress(listed, params) == address
+	}) {
+		return false
+	}
+	return len(o.Allowlist) == 0 || slices.ContainsFunc(o.Allowlist, func(listed string) bool {
+		return normalizeAddress(listed, params) == address
+	})
+}
+
+// normalizeAddress returns the canonical encoding of address for params, or
+// address with surrounding whitespace trimmed if it cannot be decoded
+func normalizeAddress(address string, params *chaincfg.Params) string {
+	address = strings.TrimSpace(address)
+	if addr, err := btcutil.DecodeAddress(address, params); err == nil && addr.IsForNet(params) {
+		return addr.EncodeAddress()
+	}
+	return address
+}
+
+// messagePrefix returns the full prefix described by the options
+func (o VerifyOptions) messagePrefix() []byte {
+	if o.MessagePrefixBytes != nil {
+		return o.MessagePrefixBytes
+	}
+	return []byte(messageMagic + o.PrefixSeparator)
+}
@@ -0,0 +1,532 @@
+package verify
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestVerifyBip137SignatureWithOptions(t *testing.T) {
+	// Signed over "Bitcoin Signed Message:" without the trailing newline
+	const noSeparatorSignature = "IM7Q9W3eSHlyzmWrP3fp9gYg8tt4loaNWPXxOcYiC/dYH3hKsNnqImQtIa0NVawNnfZg3BTBIl1mqLhVEf76Zbs="
+
+	tests := []struct {
+		name      string
+		address   string
+		message   string
+		signature string
+		opts      []Option
+		wantValid bool
+		wantErr   bool
+	}{
+		{
+			name:      "Standard signature with default prefix",
+			address:   "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			message:   "Hello, Bitcoin testing!",
+			signature: "IOeVH/0KqgmS3XKwqCJiwlcHonwxKMQN6fbOW5UsXSDZB4EGCVTXx6c+ZU/Ae5qO94MSBZn2aPOiUsupRIwBaAU=",
+			wantValid: true,
+		},
+		{
+			name:      "Standard signature with empty separator",
+			address:   "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			message:   "Hello, Bitcoin testing!",
+			signature: "IOeVH/0KqgmS3XKwqCJiwlcHonwxKMQN6fbOW5UsXSDZB4EGCVTXx6c+ZU/Ae5qO94MSBZn2aPOiUsupRIwBaAU=",
+			opts:      []Option{WithPrefixSeparator("")},
+			wantValid: false,
+		},
+		{
+			name:      "Legacy signature with default prefix",
+			address:   "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG",
+			message:   "Legacy signer without separator",
+			signature: noSeparatorSignature,
+			wantValid: false,
+		},
+		{
+			name:      "Legacy signature with empty separator",
+			address:   "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG",
+			message:   "Legacy signer without separator",
+			signature: noSeparatorSignature,
+			opts:      []Option{WithPrefixSeparator("")},
+			wantValid: true,
+		},
+		{
+			// Signed over the non-ASCII prefix "\xff\xfeFork Signed Message:\n"
+			name:      "Binary prefix",
+			address:   "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG",
+			message:   "Signed on a fork with binary magic",
+			signature: "H6Bd0zjK2pTddw8klcpXbZf4YL/85KjMx8CpLZyEEV8+RyHGVPl95JYhwCS6x0QHPK0BR94KQoeWcanXKgzXr1s=",
+			opts:      []Option{WithMessagePrefixBytes(append([]byte{0xff, 0xfe}, "Fork Signed Message:\n"...))},
+			wantValid: true,
+		},
+		{
+			name:      "Binary prefix signature with string prefix",
+			address:   "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG",
+			message:   "Signed on a fork with binary magic",
+			signature: "H6Bd0zjK2pTddw8klcpXbZf4YL/85KjMx8CpLZyEEV8+RyHGVPl95JYhwCS6x0QHPK0BR94KQoeWcanXKgzXr1s=",
+			opts:      []Option{WithMessagePrefix("\xff\xfeFork Signed Message:\n")},
+			wantValid: true,
+		},
+		{
+			name:      "Binary prefix signature with default prefix",
+			address:   "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG",
+			message:   "Signed on a fork with binary magic",
+			signature: "H6Bd0zjK2pTddw8klcpXbZf4YL/85KjMx8CpLZyEEV8+RyHGVPl95JYhwCS6x0QHPK0BR94KQoeWcanXKgzXr1s=",
+			wantValid: false,
+		},
+		{
+			name:      "Empty signature",
+			address:   "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG",
+			message:   "Legacy signer without separator",
+			signature: "",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, err := VerifyBip137SignatureWithOptions(
+				tt.address,
+				tt.message,
+				tt.signature,
+				&chaincfg.MainNetParams,
+				tt.opts...,
+			)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyBip137SignatureWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotValid != tt.wantValid {
+				t.Errorf("VerifyBip137SignatureWithOptions() = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestVerifyLedgerCompat(t *testing.T) {
+	// No signature produced by a Ledger device is available, so this vector
+	// comes from Electrum, which uses the same convention: a compressed P2PKH
+	// header byte (31-34) for a P2WPKH address. Taken from the test suite of
+	// github.com/bitonicnl/verify-signed-message v0.7.4
+	// (internal/generic/verify_test.go, "electrum - segwit native").
+	// TODO: add a Ledger-produced vector once one can be sourced.
+	const (
+		address   = "bc1qsdjne3y6ljndzvg9z9qrhje8k7p2m5yas704hn"
+		message   = "Integer can be encoded depending on the represented value to save space. Variable length integers always precede an array/vector of a type of data that may vary in length. Longer numbers are encoded in little endian. If you're reading the Satoshi client code (BitcoinQT) it refers to this encoding as a \"CompactSize\". Modern Bitcoin Core also has the VARINT macro which implements an even more compact integer for the purpose of local storage (which is incompatible with \"CompactSize\" described here). VARINT is not a part of the protocol."
+		signature = "H3TkHAXCKRfyDowCra5YRDF/Vkk2HQCel/pgEgTj9LYaWpnviSRcuYtv/CZk7NTyHsJnYP56bqbvuU3PejwLCnA="
+	)
+
+	tests := []struct {
+		name      string
+		message   string
+		opts      []Option
+		wantValid bool
+		wantErr   bool
+	}{
+		{
+			name:      "Compressed P2PKH header in strict mode",
+			message:   message,
+			wantValid: false,
+			wantErr:   true,
+		},
+		{
+			name:      "Compressed P2PKH header with LedgerCompat",
+			message:   message,
+			opts:      []Option{WithLedgerCompat()},
+			wantValid: true,
+			wantErr:   false,
+		},
+		{
+			name:      "Tampered message with LedgerCompat",
+			message:   message + "!",
+			opts:      []Option{WithLedgerCompat()},
+			wantValid: false,
+			wantErr:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, err := VerifyBip137SignatureWithOptions(address, tt.message, signature, &chaincfg.MainNetParams, tt.opts...)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyBip137SignatureWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotValid != tt.wantValid {
+				t.Errorf("VerifyBip137SignatureWithOptions() = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestVerifyTrailingNewline(t *testing.T) {
+	// Signed by a web wallet that appended "\n" to the message
+	message := "Signed in a browser wallet"
+	signature, err := SignBip137Message(testPrivKey(t), message+"\n", true)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		opts      []Option
+		wantValid bool
+	}{
+		{"Without TrailingNewline", nil, false},
+		{"With TrailingNewline", []Option{WithTrailingNewline()}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, err := VerifyBip137SignatureWithOptions(testAddress, message, signature, &chaincfg.MainNetParams, tt.opts...)
+			if err != nil {
+				t.Fatalf("VerifyBip137SignatureWithOptions() error = %v", err)
+			}
+			if gotValid != tt.wantValid {
+				t.Errorf("VerifyBip137SignatureWithOptions() = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestVerifyLenientHeader(t *testing.T) {
+	message := "Signed by an old secp256k1 binding"
+	signature, err := SignBip137Message(testPrivKey(t), message, true)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+
+	// Replace the header with the bare recovery ID
+	raw, _ := base64.StdEncoding.DecodeString(signature)
+	raw[0] = (raw[0] - 27) & 3
+	rawHeader := base64.StdEncoding.EncodeToString(raw)
+
+	tests := []struct {
+		name      string
+		address   string
+		opts      []Option
+		wantValid bool
+		wantErr   bool
+	}{
+		{"Strict mode", testAddress, nil, false, true},
+		{"Lenient P2PKH", testAddress, []Option{WithLenientHeader()}, true, false},
+		{"Lenient P2WPKH", "bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc", []Option{WithLenientHeader()}, true, false},
+		{"Lenient other address", "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", []Option{WithLenientHeader()}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, err := VerifyBip137SignatureWithOptions(tt.address, message, rawHeader, &chaincfg.MainNetParams, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyBip137SignatureWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotValid != tt.wantValid {
+				t.Errorf("VerifyBip137SignatureWithOptions() = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestVerifyStrictCompression(t *testing.T) {
+	// knownSignature has a compressed header byte, but this address is derived
+	// from the uncompressed encoding of the same key
+	uncompressed := "1QCGXrPTxmu7mmYtkdvXobAjkDhNL6j44z"
+
+	tests := []struct {
+		name      string
+		address   string
+		opts      []Option
+		wantValid bool
+		wantErr   error
+	}{
+		{"Matching address", "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", []Option{WithStrictCompression()}, true, nil},
+		{"Mismatch, default", uncompressed, nil, false, nil},
+		{"Mismatch, strict", uncompressed, []Option{WithStrictCompression()}, false, ErrCompressionMismatch},
+		{"Other address, strict", testAddress, []Option{WithStrictCompression()}, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, err := VerifyBip137SignatureWithOptions(tt.address, "Hello, Bitcoin testing!", knownSignature, &chaincfg.MainNetParams, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyBip137SignatureWithOptions() error = %v, want %v", err, tt.wantErr)
+			}
+			if gotValid != tt.wantValid {
+				t.Errorf("VerifyBip137SignatureWithOptions() = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestVerifyPrefixed(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		message string
+	}{
+		{"Signed message", "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!"},
+		{"Other message", "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing?"},
+		{"Other address", testAddress, "Hello, Bitcoin testing!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, wantErr := VerifyBip137SignatureWithOptions(tt.address, tt.message, knownSignature, &chaincfg.MainNetParams)
+
+			prefixed := formatBitcoinMessageForVerification(tt.message)
+			got, err := VerifyPrefixed(tt.address, prefixed, knownSignature, &chaincfg.MainNetParams)
+			if got != want || (err != nil) != (wantErr != nil) {
+				t.Errorf("VerifyPrefixed() = %v, %v; want %v, %v", got, err, want, wantErr)
+			}
+		})
+	}
+
+	// The prefix is not added again
+	got, _ := VerifyPrefixed("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", []byte("Hello, Bitcoin testing!"), knownSignature, &chaincfg.MainNetParams)
+	if got {
+		t.Errorf("VerifyPrefixed() = true for the unprefixed message")
+	}
+}
+
+func TestVerifyAndRecover(t *testing.T) {
+	const knownPubKey = "034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23c"
+
+	tests := []struct {
+		name       string
+		address    string
+		message    string
+		wantValid  bool
+		wantPubKey string
+		wantErr    bool
+	}{
+		{"Known vector", "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", true, knownPubKey, false},
+		{"Other address", testAddress, "Hello, Bitcoin testing!", false, knownPubKey, false},
+		{"Invalid address", "not-an-address", "Hello, Bitcoin testing!", false, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, gotPubKey, err := VerifyAndRecover(tt.address, tt.message, knownSignature, &chaincfg.MainNetParams)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyAndRecover() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotValid != tt.wantValid {
+				t.Errorf("VerifyAndRecover() valid = %v, want %v", gotValid, tt.wantValid)
+			}
+
+			var got string
+			if gotPubKey != nil {
+				got = hex.EncodeToString(gotPubKey.SerializeCompressed())
+			}
+			if got != tt.wantPubKey {
+				t.Errorf("VerifyAndRecover() pubKey = %s, want %s", got, tt.wantPubKey)
+			}
+		})
+	}
+}
+
+func TestVerifyPrehashed(t *testing.T) {
+	digest := sha256.Sum256([]byte("contents of a signed document"))
+	signature, err := SignBip137Message(testPrivKey(t), string(digest[:]), true)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		message   string
+		opts      []Option
+		wantValid bool
+		wantErr   error
+	}{
+		{"Prehashed digest", hex.EncodeToString(digest[:]), []Option{WithPrehashed()}, true, nil},
+		{"Digest as plain message", hex.EncodeToString(digest[:]), nil, false, nil},
+		{"Not hex", "contents of a signed document", []Option{WithPrehashed()}, false, ErrInvalidPrehash},
+		{"Wrong length", hex.EncodeToString(digest[:20]), []Option{WithPrehashed()}, false, ErrInvalidPrehash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, err := VerifyBip137SignatureWithOptions(testAddress, tt.message, signature, &chaincfg.MainNetParams, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyBip137SignatureWithOptions() error = %v, want %v", err, tt.wantErr)
+			}
+			if gotValid != tt.wantValid {
+				t.Errorf("VerifyBip137SignatureWithOptions() = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestVerifyAddressLists(t *testing.T) {
+	const address = "bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc"
+	const message = "Only listed addresses may sign in"
+	signature, err := SignBip137Message(testPrivKey(t), message, true)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		address   string
+		opts      []Option
+		wantValid bool
+		wantErr   error
+	}{
+		{"No lists", testAddress, nil, true, nil},
+		{"Allowlisted", testAddress, []Option{WithAllowlist(" " + testAddress + " ")}, true, nil},
+		{"Allowlisted, uppercase bech32", address, []Option{WithAllowlist("BC1QNY80VRTRKK6EVJSUY2PQVXH52Y37J07TQCETCC"), WithElectrumCompat()}, true, nil},
+		{"Not allowlisted", testAddress, []Option{WithAllowlist("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9")}, false, ErrAddressNotAllowed},
+		{"Blocklisted despite valid signature", testAddress, []Option{WithBlocklist(testAddress)}, false, ErrAddressNotAllowed},
+		{"Blocklist wins over allowlist", testAddress, []Option{WithAllowlist(testAddress), WithBlocklist(testAddress)}, false, ErrAddressNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, err := VerifyBip137SignatureWithOptions(tt.address, message, signature, &chaincfg.MainNetParams, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyBip137SignatureWithOptions() error = %v, want %v", err, tt.wantErr)
+			}
+			if gotValid != tt.wantValid {
+				t.Errorf("VerifyBip137SignatureWithOptions() = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestVerifyCommitment(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		message string
+	}{
+		{"Known vector", "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!"},
+		{"Different message", "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin!"},
+		{"Different address", testAddress, "Hello, Bitcoin testing!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, wantErr := VerifyBip137SignatureWithOptions(tt.address, tt.message, knownSignature, &chaincfg.MainNetParams)
+			got, err := VerifyCommitment(tt.address, MessageHash(tt.message), knownSignature, &chaincfg.MainNetParams)
+			if got != want || (err != nil) != (wantErr != nil) {
+				t.Errorf("VerifyCommitment() = %v, %v, want %v, %v", got, err, want, wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyBip137SignatureRaw(t *testing.T) {
+	raw, _ := base64.StdEncoding.DecodeString(knownSignature)
+
+	tests := []struct {
+		name    string
+		address string
+		message string
+	}{
+		{"Known vector", "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!"},
+		{"Different message", "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin!"},
+		{"Different address", testAddress, "Hello, Bitcoin testing!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, wantErr := VerifyBip137SignatureWithOptions(tt.address, tt.message, knownSignature, &chaincfg.MainNetParams)
+			got, err := VerifyBip137SignatureRaw(tt.address, tt.message, raw, &chaincfg.MainNetParams)
+			if got != want || (err != nil) != (wantErr != nil) {
+				t.Errorf("VerifyBip137SignatureRaw() = %v, %v, want %v, %v", got, err, want, wantErr)
+			}
+		})
+	}
+
+	if _, err := VerifyBip137SignatureRaw("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", raw[:64], &chaincfg.MainNetParams); !errors.Is(err, ErrBadLength) {
+		t.Errorf("VerifyBip137SignatureRaw() error = %v, want ErrBadLength", err)
+	}
+
+	badHeader := append([]byte{26}, raw[1:]...)
+	if _, err := VerifyBip137SignatureRaw("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", badHeader, &chaincfg.MainNetParams); !errors.Is(err, ErrBadHeader) {
+		t.Errorf("VerifyBip137SignatureRaw() error = %v, want ErrBadHeader", err)
+	}
+}
+
+func TestWithLenientLength(t *testing.T) {
+	raw, _ := base64.StdEncoding.DecodeString(knownSignature)
+	trailing := base64.StdEncoding.EncodeToString(append(raw, 0x01))
+
+	tests := []struct {
+		name      string
+		opts      []Option
+		wantValid bool
+		wantErr   error
+	}{
+		{name: "Strict", wantErr: ErrBadLength},
+		{name: "Lenient", opts: []Option{WithLenientLength()}, wantValid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := VerifyBip137SignatureWithOptions("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", trailing, &chaincfg.MainNetParams, tt.opts...)
+			if valid != tt.wantValid || !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyBip137SignatureWithOptions() = %v, %v, want %v, %v", valid, err, tt.wantValid, tt.wantErr)
+			}
+		})
+	}
+
+	// Short signatures are rejected either way
+	_, err := VerifyBip137SignatureWithOptions("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", base64.StdEncoding.EncodeToString(raw[:64]), &chaincfg.MainNetParams, WithLenientLength())
+	if !errors.Is(err, ErrBadLength) {
+		t.Errorf("VerifyBip137SignatureWithOptions() error = %v, want ErrBadLength", err)
+	}
+}
+
+func TestCouldMatch(t *testing.T) {
+	raw, _ := base64.StdEncoding.DecodeString(knownSignature)
+	withHeader := func(header byte) string {
+		return base64.StdEncoding.EncodeToString(append([]byte{header}, raw[1:]...))
+	}
+
+	const (
+		p2pkh      = "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG"
+		p2shP2wpkh = "3291hXxutb58vbDVVumaJpopanmfxjVpgJ"
+		p2wpkh     = "bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc"
+	)
+
+	tests := []struct {
+		name    string
+		address string
+		header  byte
+		want    bool
+		wantErr error
+	}{
+		{"Uncompressed P2PKH header, P2PKH", p2pkh, 28, true, nil},
+		{"Compressed P2PKH header, P2PKH", p2pkh, 32, true, nil},
+		{"P2SH-P2WPKH header, P2SH-P2WPKH", p2shP2wpkh, 36, true, nil},
+		{"P2WPKH header, P2WPKH", p2wpkh, 40, true, nil},
+		{"Compressed P2PKH header, P2WPKH (Electrum)", p2wpkh, 32, true, nil},
+		{"Testnet P2PKH", "muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz", 32, true, nil},
+		{"Uncompressed P2PKH header, P2WPKH", p2wpkh, 28, false, nil},
+		{"P2WPKH header, P2PKH", p2pkh, 40, false, nil},
+		{"P2WPKH header, P2SH-P2WPKH", p2shP2wpkh, 40, false, nil},
+		{"P2SH-P2WPKH header, P2WPKH", p2wpkh, 36, false, nil},
+		{"Invalid header", p2pkh, 43, false, ErrBadHeader},
+		{"P2TR", "bc1ppv609nr0vr25u07u95waq5lucwfm6tde4nydujnu8npg4q75mr5sxq8lt3", 32, false, ErrUnsupportedAddressType},
+		{"Invalid address", "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UH", 32, false, ErrInvalidAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CouldMatch(tt.address, withHeader(tt.header))
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("CouldMatch() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CouldMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
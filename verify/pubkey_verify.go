@@ -1,19 +1,34 @@
 package verify
 
 import (
-	"crypto/sha256"
-	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
-	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 
 	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 )
 
-func VerifyWithPubKey(pubKey *secp256k1.PublicKey, message, signatureBase64 string) (bool, error) {
+// VerifyWithPubKey verifies a BIP-0137 signature against a public key. The
+// key is a *btcec.PublicKey, like everywhere else in the package's API; keys
+// parsed with github.com/decred/dcrd/dcrec/secp256k1/v4 can be passed as is,
+// since btcec.PublicKey is an alias of that package's type.
+func VerifyWithPubKey(pubKey *btcec.PublicKey, message, signatureBase64 string) (bool, error) {
+	if pubKey == nil {
+		return false, errors.New("nil public key")
+	}
+
+	msg := SignedMessage{Address: hex.EncodeToString(pubKey.SerializeCompressed()), Message: message, Signature: signatureBase64}
+	return audited(msg, &chaincfg.MainNetParams, messageDigest(message, DefaultVerifyOptions()), func() (bool, error) {
+		return verifyWithPubKey(pubKey, message, signatureBase64)
+	})
+}
+
+// verifyWithPubKey implements VerifyWithPubKey
+func verifyWithPubKey(pubKey *btcec.PublicKey, message, signatureBase64 string) (bool, error) {
 	// First attempt: Direct verification with public key
 	valid, err := verifySignatureDirectly(pubKey, message, signatureBase64)
 	if err == nil {
@@ -21,20 +36,41 @@ func VerifyWithPubKey(pubKey *secp256k1.PublicKey, message, signatureBase64 stri
 	}
 
 	// Second attempt: Derive address and use address-based verification
-	return verifyWithDerivedAddress(pubKey, message, signatureBase64)
+	valid, fallbackErr := verifyWithDerivedAddress(pubKey, message, signatureBase64)
+	if fallbackErr != nil {
+		// Keep both errors, so that the btcec error behind either is retrievable
+		return false, errors.Join(err, fallbackErr)
+	}
+	return valid, nil
+}
+
+// VerifyWithPubKeyHex verifies a BIP-0137 signature against a hex-encoded public
+// key in either the 33-byte compressed or the 65-byte uncompressed form.
+func VerifyWithPubKeyHex(pubKeyHex, message, signatureBase64 string) (bool, error) {
+	pubKeyBytes, err := hex.DecodeString(pubKeyHex)
+	if err != nil {
+		return false, fmt.Errorf("invalid public key hex: %w", err)
+	}
+
+	if len(pubKeyBytes) != btcec.PubKeyBytesLenCompressed && len(pubKeyBytes) != 65 {
+		return false, fmt.Errorf("invalid public key length: %d (expected 33 or 65 bytes)", len(pubKeyBytes))
+	}
+
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		return false, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	return VerifyWithPubKey(pubKey, message, signatureBase64)
 }
 
 // verifySignatureDirectly attempts to verify a Bitcoin message signature directly
 // using the provided public key.
 func verifySignatureDirectly(pubKey *btcec.PublicKey, message, signatureBase64 string) (bool, error) {
 	// Decode signature from base64
-	sigBytes, err := base64.StdEncoding.DecodeString(signatureBase64)
+	sigBytes, err := DecodeSignature(signatureBase64)
 	if err != nil {
-		return false, fmt.Errorf("invalid base64 signature: %w", err)
-	}
-
-	if len(sigBytes) < 65 {
-		return false, fmt.Errorf("signature too short (expected at least 65 bytes)")
+		return false, err
 	}
 
 	// Extract recovery ID and signature components
@@ -42,12 +78,11 @@ func verifySignatureDirectly(pubKey *btcec.PublicKey, message, signatureBase64 s
 	LogDebug("Signature header byte: 0x%02x", headerByte)
 
 	// Verify header byte is valid per BIP-137
-	recoveryID := int(headerByte-27) % 4
+	recoveryID := int(headerByte-MinHeaderByte) % 4
 	isCompressed := headerByte >= 31 // 31-34 = compressed, 27-30 = uncompressed
 
 	// Check that the header byte is within valid ranges for a standard Bitcoin signature
-	if (headerByte < 27 || headerByte > 34) &&
-		(headerByte < 35 || headerByte > 42) {
+	if headerByte < MinHeaderByte || headerByte > MaxHeaderByte {
 		LogError("Invalid header byte: 0x%02x", headerByte)
 		return false, fmt.Errorf("invalid signature header byte: 0x%02x", headerByte)
 	}
@@ -55,18 +90,14 @@ func verifySignatureDirectly(pubKey *btcec.PublicKey, message, signatureBase64 s
 	LogDebug("Recovery ID: %d, Compressed: %t", recoveryID, isCompressed)
 
 	// Format the message according to Bitcoin signed message format and hash it
-	formattedMsg := formatBitcoinMessageForVerification(message)
-
-	// Double SHA-256 hash the formatted message
-	messageHash := sha256.Sum256(formattedMsg)
-	messageHash = sha256.Sum256(messageHash[:])
+	messageHash := hashBitcoinMessage(message, DefaultVerifyOptions())
 
 	// Extract the R and S components (bytes 1-33 and 33-65)
 	rBytes := sigBytes[1:33]
-	sBytes := sigBytes[33:65]
+	sBytes := sigBytes[33:CompactSignatureLength]
 
-	LogDebug("Signature R component: %x", rBytes)
-	LogDebug("Signature S component: %x", sBytes)
+	LogDebug("Signature R component: %s", redacted(rBytes))
+	LogDebug("Signature S component: %s", redacted(sBytes))
 
 	// Create a DER signature from R and S components
 	// Standard DER format:
@@ -110,7 +141,7 @@ func verifySignatureDirectly(pubKey *btcec.PublicKey, message, signatureBase64 s
 	der[5+rLen] = byte(sLen)   // Length of S
 	copy(der[6+rLen:], sBytes) // S value
 
-	LogDebug("Created DER signature: %x", der)
+	LogDebug("Created DER signature: %s", redacted(der))
 
 	// Parse the DER signature
 	signature, err := ecdsa.ParseDERSignature(der)
@@ -120,7 +151,7 @@ func verifySignatureDirectly(pubKey *btcec.PublicKey, message, signatureBase64 s
 	}
 
 	// Verify the signature against the message hash and public key
-	valid := signature.Verify(messageHash[:], pubKey)
+	valid := signature.Verify(messageHash, pubKey)
 
 	LogDebug("Direct verification result: %v", valid)
 	return valid, nil
@@ -131,28 +162,36 @@ func verifySignatureDirectly(pubKey *btcec.PublicKey, message, signatureBase64 s
 func verifyWithDerivedAddress(pubKey *btcec.PublicKey, message, signatureBase64 string) (bool, error) {
 	// Determine if the signature uses a compressed or uncompressed key
 	// from the signature header byte
-	sigBytes, err := base64.StdEncoding.DecodeString(signatureBase64)
+	sigBytes, err := DecodeSignature(signatureBase64)
 	if err != nil {
-		return false, fmt.Errorf("invalid base64 signature: %w", err)
+		return false, err
 	}
 
-	if len(sigBytes) < 1 {
-		return false, fmt.Errorf("signature too short")
-	}
+	// Header bytes 27-30 commit to the uncompressed serialization of the key
+	compressed := sigBytes[0] >= 31
 
 	// Derive the address from the public key
-	derivedAddress, err := deriveAddressFromPubKey(pubKey, &chaincfg.MainNetParams)
+	derivedAddress, err := deriveAddressFromPubKey(pubKey, compressed, &chaincfg.MainNetParams)
 	if err != nil {
 		return false, fmt.Errorf("failed to derive address from public key: %w", err)
 	}
 
-	return VerifyBip137Signature(derivedAddress, message, signatureBase64)
+	return verifyBip137SignatureWithParams(derivedAddress, message, signatureBase64, &chaincfg.MainNetParams)
 }
 
-// deriveAddressFromPubKey derives a Bitcoin address from a public key
-func deriveAddressFromPubKey(pubKey *btcec.PublicKey, params *chaincfg.Params) (string, error) {
+// deriveAddressFromPubKey derives a P2PKH address from a public key. The hash160
+// is taken over the compressed or uncompressed serialization of the key, as
+// selected by compressed, since the two forms produce different addresses.
+func deriveAddressFromPubKey(pubKey *btcec.PublicKey, compressed bool, params *chaincfg.Params) (string, error) {
+	var serialized []byte
+	if compressed {
+		serialized = pubKey.SerializeCompressed()
+	} else {
+		serialized = pubKey.SerializeUncompressed()
+	}
+
 	// Convert the public key to a btcutil.Address
-	pubKeyHash := btcutil.Hash160(pubKey.SerializeCompressed())
+	pubKeyHash := btcutil.Hash160(serialized)
 	addr, err := btcutil.NewAddressPubKeyHash(pubKeyHash, params)
 	if err != nil {
 		return "", fmt.Errorf("error creating address from public key: %w", err)
@@ -163,15 +202,23 @@ func deriveAddressFromPubKey(pubKey *btcec.PublicKey, params *chaincfg.Params) (
 }
 
 // DeriveAddressFromPubKey derives a Bitcoin address from a public key using mainnet parameters.
-// This is a utility function that can be used by external code.
+// This is a utility function that can be used by external code. The compressed
+// form of the key is used.
 func DeriveAddressFromPubKey(pubKey *btcec.PublicKey) (string, error) {
-	return deriveAddressFromPubKey(pubKey, &chaincfg.MainNetParams)
+	return deriveAddressFromPubKey(pubKey, true, &chaincfg.MainNetParams)
 }
 
 // formatBitcoinMessageForVerification formats a message according to the Bitcoin
 // signed message format: "Bitcoin Signed Message:\n" + message
 func formatBitcoinMessageForVerification(message string) []byte {
-	prefix := "Bitcoin Signed Message:\n"
+	return formatBitcoinMessage(message, DefaultVerifyOptions())
+}
+
+// formatBitcoinMessage formats a message using the prefix described by opts.
+// The message is used byte for byte; line endings such as "\r\n" are not
+// normalized, as signers hash exactly the bytes they were given.
+func formatBitcoinMessage(message string, opts VerifyOptions) []byte {
+	prefixBytes := opts.messagePrefix()
 
 	// Bitcoin's message format uses a compact size encoding for the lengths
 	// Prefix: "Bitcoin Signed Message:\n"
@@ -181,16 +228,18 @@ func formatBitcoinMessageForVerification(message string) []byte {
 	var result []byte
 
 	// Add the prefix with varint length
-	prefixBytes := []byte(prefix)
 	result = appendCompactSize(result, uint64(len(prefixBytes)))
 	result = append(result, prefixBytes...)
 
 	// Add the message with varint length
 	messageBytes := []byte(message)
+	if opts.TrailingNewline {
+		messageBytes = append(messageBytes, '\n')
+	}
 	result = appendCompactSize(result, uint64(len(messageBytes)))
 	result = append(result, messageBytes...)
 
-	LogTrace("Formatted Bitcoin message (hex): %x", result)
+	LogTrace("Formatted Bitcoin message (hex): %s", redacted(result))
 	return result
 }
 
@@ -0,0 +1,298 @@
+package verify
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+)
+
+func FuzzFormatBitcoinMessage(f *testing.F) {
+	// Seed with messages whose lengths fall in each compact size class that
+	// fits in memory
+	f.Add("")
+	f.Add("Hello, Bitcoin testing!")
+	f.Add(strings.Repeat("a", 0xfd))
+	f.Add(strings.Repeat("a", 0x10000))
+
+	prefix := DefaultVerifyOptions().messagePrefix()
+	f.Fuzz(func(t *testing.T, message string) {
+		formatted := bytes.NewReader(formatBitcoinMessageForVerification(message))
+
+		// The output must parse back as the length-prefixed prefix and message
+		for _, want := range [][]byte{prefix, []byte(message)} {
+			n, err := wire.ReadVarInt(formatted, 0)
+			if err != nil {
+				t.Fatalf("ReadVarInt() error = %v", err)
+			}
+			if n != uint64(len(want)) {
+				t.Fatalf("encoded length = %d, want %d", n, len(want))
+			}
+			got := make([]byte, n)
+			if _, err := io.ReadFull(formatted, got); err != nil {
+				t.Fatalf("ReadFull() error = %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("encoded bytes = %q, want %q", got, want)
+			}
+		}
+		if formatted.Len() != 0 {
+			t.Fatalf("%d trailing bytes after the message", formatted.Len())
+		}
+	})
+}
+
+func TestAppendCompactSize(t *testing.T) {
+	tests := []struct {
+		name string
+		n    uint64
+		want []byte
+	}{
+		{"Largest single byte", 0xfc, []byte{0xfc}},
+		{"Smallest two byte", 0xfd, []byte{0xfd, 0xfd, 0x00}},
+		{"Largest two byte", 0xffff, []byte{0xfd, 0xff, 0xff}},
+		{"Smallest four byte", 0x10000, []byte{0xfe, 0x00, 0x00, 0x01, 0x00}},
+		{"Largest four byte", 0xffffffff, []byte{0xfe, 0xff, 0xff, 0xff, 0xff}},
+		{"Smallest eight byte", 0x100000000, []byte{0xff, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00}},
+		{"Eight byte ordering", 0x0102030405060708, []byte{0xff, 0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01}},
+		{"Largest eight byte", math.MaxUint64, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := appendCompactSize([]byte{0xaa}, tt.n)
+			if !bytes.Equal(got[1:], tt.want) || got[0] != 0xaa {
+				t.Errorf("appendCompactSize(%#x) = %x, want aa%x", tt.n, got, tt.want)
+			}
+
+			// The encoding must agree with the wire package and round-trip
+			var buf bytes.Buffer
+			if err := wire.WriteVarInt(&buf, 0, tt.n); err != nil {
+				t.Fatalf("WriteVarInt() error = %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.want) {
+				t.Errorf("wire.WriteVarInt(%#x) = %x, want %x", tt.n, buf.Bytes(), tt.want)
+			}
+
+			n, err := wire.ReadVarInt(bytes.NewReader(got[1:]), 0)
+			if err != nil || n != tt.n {
+				t.Errorf("wire.ReadVarInt() = %#x, %v; want %#x, nil", n, err, tt.n)
+			}
+		})
+	}
+}
+
+func TestVerifyWithDerivedAddressUncompressed(t *testing.T) {
+	const (
+		message   = "Signed with an uncompressed key"
+		signature = "HNREVP+ijXWttIk6GXlCzsKK8RhS3U3MZeA6t9zcYMu8FaldLWI4g/OZ3RZOtjglp9d2vjEHLXKBRvEyfOx/u9s="
+
+		// P2PKH addresses of the same key in both serializations
+		uncompressedAddress = "1ELReFsTCUY2mfaDTy32qxYiT49z786eFg"
+		compressedAddress   = "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG"
+	)
+
+	pubKey := testPrivKey(t).PubKey()
+
+	tests := []struct {
+		name       string
+		compressed bool
+		want       string
+	}{
+		{name: "Compressed", compressed: true, want: compressedAddress},
+		{name: "Uncompressed", compressed: false, want: uncompressedAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := deriveAddressFromPubKey(pubKey, tt.compressed, &chaincfg.MainNetParams)
+			if err != nil {
+				t.Fatalf("deriveAddressFromPubKey() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("deriveAddressFromPubKey() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	valid, err := verifyWithDerivedAddress(pubKey, message, signature)
+	if err != nil || !valid {
+		t.Errorf("verifyWithDerivedAddress() = %v, %v; want true, nil", valid, err)
+	}
+}
+
+func TestVerifyWithPubKeyHex(t *testing.T) {
+	tests := []struct {
+		name      string
+		pubKeyHex string
+		message   string
+		wantValid bool
+		wantErr   bool
+	}{
+		{
+			name:      "Compressed public key",
+			pubKeyHex: "034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23c",
+			message:   "Hello, Bitcoin testing!",
+			wantValid: true,
+		},
+		{
+			name:      "Uncompressed public key",
+			pubKeyHex: "044fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23cf1814f2c277aa4c700dfc671b7f8e86197daaa2cb93b4dc782a378633f78f869",
+			message:   "Hello, Bitcoin testing!",
+			wantValid: true,
+		},
+		{
+			name:      "Malformed hex",
+			pubKeyHex: "034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23z",
+			message:   "Hello, Bitcoin testing!",
+			wantErr:   true,
+		},
+		{
+			name:      "Wrong length",
+			pubKeyHex: "034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e2",
+			message:   "Hello, Bitcoin testing!",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, err := VerifyWithPubKeyHex(tt.pubKeyHex, tt.message, knownSignature)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyWithPubKeyHex() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotValid != tt.wantValid {
+				t.Errorf("VerifyWithPubKeyHex() = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestFormatBitcoinMessageCRLF(t *testing.T) {
+	message := "First line\r\nSecond line\r\n"
+
+	// The message bytes follow the prefix and length unchanged
+	formatted := formatBitcoinMessage(message, DefaultVerifyOptions())
+	if !bytes.HasSuffix(formatted, []byte(message)) {
+		t.Errorf("formatBitcoinMessage() = %q, want it to end with %q", formatted, message)
+	}
+
+	signature, err := SignBip137Message(testPrivKey(t), message, true)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		message   string
+		wantValid bool
+	}{
+		{"Exact CRLF bytes", message, true},
+		{"Carriage returns stripped", "First line\nSecond line\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := VerifyBip137SignatureWithOptions(testAddress, tt.message, signature, &chaincfg.MainNetParams)
+			if err != nil {
+				t.Fatalf("VerifyBip137SignatureWithOptions() error = %v", err)
+			}
+			if valid != tt.wantValid {
+				t.Errorf("VerifyBip137SignatureWithOptions() = %v, want %v", valid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestVerifyWithPubKey(t *testing.T) {
+	pubKeyBytes, _ := hex.DecodeString("034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23c")
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		t.Fatalf("ParsePubKey() error = %v", err)
+	}
+
+	valid, err := VerifyWithPubKey(pubKey, "Hello, Bitcoin testing!", knownSignature)
+	if err != nil || !valid {
+		t.Errorf("VerifyWithPubKey() = %v, %v; want true, nil", valid, err)
+	}
+
+	valid, _ = VerifyWithPubKey(testPrivKey(t).PubKey(), "Hello, Bitcoin testing!", knownSignature)
+	if valid {
+		t.Errorf("VerifyWithPubKey() = true for another key")
+	}
+
+	if _, err := VerifyWithPubKey(nil, "Hello, Bitcoin testing!", knownSignature); err == nil {
+		t.Errorf("VerifyWithPubKey() expected error for nil key")
+	}
+
+	// Whitespace is ignored on both the direct and the derived address path
+	wrapped := "  " + knownSignature[:44] + "\r\n\t" + knownSignature[44:] + " \n"
+	if valid, err := VerifyWithPubKey(pubKey, "Hello, Bitcoin testing!", wrapped); err != nil || !valid {
+		t.Errorf("VerifyWithPubKey() = %v, %v for a wrapped signature; want true, nil", valid, err)
+	}
+	if valid, err := verifySignatureDirectly(pubKey, "Hello, Bitcoin testing!", wrapped); err != nil || !valid {
+		t.Errorf("verifySignatureDirectly() = %v, %v for a wrapped signature; want true, nil", valid, err)
+	}
+	if valid, err := verifyWithDerivedAddress(pubKey, "Hello, Bitcoin testing!", wrapped); err != nil || !valid {
+		t.Errorf("verifyWithDerivedAddress() = %v, %v for a wrapped signature; want true, nil", valid, err)
+	}
+
+	messageHash := MessageHash("Hello, Bitcoin testing!")
+	der := base64.StdEncoding.EncodeToString(ecdsa.Sign(testPrivKey(t), messageHash[:]).Serialize())
+	if _, err := VerifyWithPubKey(testPrivKey(t).PubKey(), "Hello, Bitcoin testing!", der); !errors.Is(err, ErrUnexpectedDER) {
+		t.Errorf("VerifyWithPubKey() error = %v for a DER signature, want ErrUnexpectedDER", err)
+	}
+}
+
+func TestCompactSignatureConstants(t *testing.T) {
+	if CompactSignatureLength != 65 {
+		t.Errorf("CompactSignatureLength = %d, want 65", CompactSignatureLength)
+	}
+	if MinHeaderByte != 27 || MaxHeaderByte != 42 {
+		t.Errorf("header byte range = %d-%d, want 27-42", MinHeaderByte, MaxHeaderByte)
+	}
+
+	pubKeyBytes, _ := hex.DecodeString("034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23c")
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		t.Fatalf("ParsePubKey() error = %v", err)
+	}
+	sig, _ := base64.StdEncoding.DecodeString(knownSignature)
+
+	withHeader := func(header byte) string {
+		b := append([]byte(nil), sig...)
+		b[0] = header
+		return base64.StdEncoding.EncodeToString(b)
+	}
+
+	tests := []struct {
+		name      string
+		signature string
+		wantErr   bool
+	}{
+		{"Shorter than CompactSignatureLength", base64.StdEncoding.EncodeToString(sig[:CompactSignatureLength-1]), true},
+		{"Below MinHeaderByte", withHeader(MinHeaderByte - 1), true},
+		{"Above MaxHeaderByte", withHeader(MaxHeaderByte + 1), true},
+		{"MaxHeaderByte", withHeader(MaxHeaderByte), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifySignatureDirectly(pubKey, "Hello, Bitcoin testing!", tt.signature)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifySignatureDirectly() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package verify
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestRandomSignedMessage(t *testing.T) {
+	rng := rand.New(rand.NewSource(137))
+
+	for _, params := range []*chaincfg.Params{&chaincfg.MainNetParams, &chaincfg.TestNet3Params} {
+		t.Run(params.Name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				msg, privKey, err := RandomSignedMessage(rng, params)
+				if err != nil {
+					t.Fatalf("RandomSignedMessage() error = %v", err)
+				}
+				if privKey == nil || msg.Message == "" {
+					t.Fatalf("RandomSignedMessage() = %+v, %v", msg, privKey)
+				}
+
+				valid, err := VerifyBip137SignatureWithOptions(msg.Address, msg.Message, msg.Signature, params)
+				if err != nil || !valid {
+					t.Errorf("VerifyBip137SignatureWithOptions(%+v) = %v, %v; want true, nil", msg, valid, err)
+				}
+			}
+		})
+	}
+}
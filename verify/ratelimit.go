@@ -0,0 +1,72 @@
+package verify
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// ErrRateLimited is returned by RateLimitedVerifier when its rate is exceeded
+var ErrRateLimited = errors.New("signature verification rate limit exceeded")
+
+// RateLimitedVerifier wraps a Verifier with a token-bucket limiter. Public key
+// recovery is CPU-intensive, so endpoints that verify untrusted input should
+// bound how many signatures they check.
+type RateLimitedVerifier struct {
+	verifier Verifier
+	rate     float64 // tokens added per second
+	burst    float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimitedVerifier returns a RateLimitedVerifier allowing rate
+// verifications per second on average and bursts of up to burst verifications.
+// A nil verifier wraps the package's current verifier, as returned by
+// GetVerifier when the limiter is created, so that the limiter can itself be
+// installed with SetVerifier.
+func NewRateLimitedVerifier(v Verifier, rate float64, burst int) *RateLimitedVerifier {
+	if v == nil {
+		v = GetVerifier()
+	}
+	return &RateLimitedVerifier{
+		verifier: v,
+		rate:     rate,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     timeSource.Now(),
+	}
+}
+
+// Verify implements Verifier. It returns ErrRateLimited without verifying when
+// no token is available.
+func (r *RateLimitedVerifier) Verify(msg SignedMessage, params *chaincfg.Params) (bool, error) {
+	if !r.allow() {
+		LogWarning("Rate limit exceeded verifying signature for %s", msg.Address)
+		return false, ErrRateLimited
+	}
+
+	return r.verifier.Verify(msg, params)
+}
+
+// allow takes a token from the bucket if one is available
+func (r *RateLimitedVerifier) allow() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := timeSource.Now()
+	if elapsed := now.Sub(r.last).Seconds(); elapsed > 0 {
+		r.tokens = min(r.burst, r.tokens+elapsed*r.rate)
+	}
+	r.last = now
+
+	if r.tokens < 1 {
+		return false
+	}
+	r.tokens--
+	return true
+}
@@ -0,0 +1,77 @@
+package verify
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestRateLimitedVerifier(t *testing.T) {
+	calls := 0
+	inner := VerifierFunc(func(msg SignedMessage, params *chaincfg.Params) (bool, error) {
+		calls++
+		return true, nil
+	})
+
+	defer func(c clock) { timeSource = c }(timeSource)
+	now := &fakeClock{now: time.Unix(0, 0)}
+	timeSource = now
+	limiter := NewRateLimitedVerifier(inner, 2, 3)
+
+	msg := SignedMessage{Address: "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", Message: "Hello, Bitcoin testing!", Signature: knownSignature}
+
+	// The burst is available immediately
+	for i := 0; i < 3; i++ {
+		if valid, err := limiter.Verify(msg, &chaincfg.MainNetParams); err != nil || !valid {
+			t.Fatalf("Verify() #%d = %v, %v; want true, nil", i, valid, err)
+		}
+	}
+
+	if _, err := limiter.Verify(msg, &chaincfg.MainNetParams); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Verify() error = %v, want ErrRateLimited", err)
+	}
+	if calls != 3 {
+		t.Errorf("inner verifier called %d times, want 3", calls)
+	}
+
+	// Half a second refills one token at two per second
+	now.now = now.now.Add(500 * time.Millisecond)
+	if valid, err := limiter.Verify(msg, &chaincfg.MainNetParams); err != nil || !valid {
+		t.Errorf("Verify() after refill = %v, %v; want true, nil", valid, err)
+	}
+	if _, err := limiter.Verify(msg, &chaincfg.MainNetParams); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Verify() error = %v, want ErrRateLimited", err)
+	}
+
+	// The bucket never holds more than the burst
+	now.now = now.now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		if _, err := limiter.Verify(msg, &chaincfg.MainNetParams); err != nil {
+			t.Fatalf("Verify() #%d error = %v", i, err)
+		}
+	}
+	if _, err := limiter.Verify(msg, &chaincfg.MainNetParams); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Verify() error = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestRateLimitedVerifierInstalled(t *testing.T) {
+	defer func(c clock) { timeSource = c }(timeSource)
+	timeSource = &fakeClock{now: time.Unix(0, 0)}
+	t.Cleanup(func() { SetVerifier(nil) })
+
+	// A nil verifier wraps the default verifier rather than the limiter
+	SetVerifier(NewRateLimitedVerifier(nil, 1, 2))
+
+	for i := 0; i < 2; i++ {
+		valid, err := VerifyBip137Signature("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", knownSignature)
+		if err != nil || !valid {
+			t.Fatalf("VerifyBip137Signature() #%d = %v, %v; want true, nil", i, valid, err)
+		}
+	}
+	if _, err := VerifyBip137Signature("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", knownSignature); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("VerifyBip137Signature() error = %v, want ErrRateLimited", err)
+	}
+}
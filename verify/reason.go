@@ -0,0 +1,90 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// FailReason classifies why a verification failed, so that callers can act on
+// it without parsing error strings
+type FailReason int
+
+const (
+	// FailNone means the signature is valid
+	FailNone FailReason = iota
+
+	// FailDecode means an input is empty or cannot be decoded, such as an
+	// address that is malformed or of a type BIP-0137 does not define, or a
+	// signature that is not base64 or is DER-encoded
+	FailDecode
+
+	// FailLength means the signature does not decode to 65 bytes
+	FailLength
+
+	// FailHeader means the header byte is not defined by BIP-0137 or does not
+	// fit the address type
+	FailHeader
+
+	// FailRecover means no public key can be recovered from the signature
+	FailRecover
+
+	// FailMismatch means the recovered key does not belong to the address
+	FailMismatch
+
+	// FailNetwork means the address belongs to another network than params
+	FailNetwork
+)
+
+// failReasonNames holds the display name of each FailReason
+var failReasonNames = map[FailReason]string{
+	FailNone:     "none",
+	FailDecode:   "decode",
+	FailLength:   "length",
+	FailHeader:   "header",
+	FailRecover:  "recover",
+	FailMismatch: "mismatch",
+	FailNetwork:  "network",
+}
+
+// String returns the name of the reason, e.g. "mismatch"
+func (r FailReason) String() string {
+	if name, ok := failReasonNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("FailReason(%d)", int(r))
+}
+
+// VerifyWithReason verifies msg like VerifyBip137SignatureWithOptions with
+// default options and also returns why verification failed, or FailNone when
+// the signature is valid. The error is the one verification returned; it is
+// nil for FailMismatch.
+func VerifyWithReason(msg SignedMessage, params *chaincfg.Params) (bool, FailReason, error) {
+	valid, err := VerifyBip137SignatureWithOptions(msg.Address, msg.Message, msg.Signature, params)
+	switch {
+	case err != nil:
+		return false, failReason(msg.Address, err), err
+	case !valid:
+		return false, FailMismatch, nil
+	default:
+		return true, FailNone, nil
+	}
+}
+
+// failReason classifies a verification error for address
+func failReason(address string, err error) FailReason {
+	switch {
+	case errors.Is(err, ErrInvalidAddress) && findAddressNetwork(address) != nil:
+		return FailNetwork
+	case errors.Is(err, ErrBadLength):
+		return FailLength
+	case errors.Is(err, ErrBadHeader), errors.Is(err, ErrUncompressedSegwit), errors.Is(err, ErrCompressionMismatch):
+		return FailHeader
+	case errors.Is(err, ErrRecoveryFailed):
+		return FailRecover
+	default:
+		// The remaining errors reject the inputs before any key is recovered
+		return FailDecode
+	}
+}
@@ -0,0 +1,53 @@
+package verify
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestVerifyWithReason(t *testing.T) {
+	raw, _ := base64.StdEncoding.DecodeString(knownSignature)
+	withHeader := func(header byte) string {
+		return base64.StdEncoding.EncodeToString(append([]byte{header}, raw[1:]...))
+	}
+	zeroR := base64.StdEncoding.EncodeToString(append(append([]byte{raw[0]}, make([]byte, 32)...), raw[33:]...))
+
+	const (
+		address = "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9"
+		message = "Hello, Bitcoin testing!"
+	)
+
+	tests := []struct {
+		name       string
+		msg        SignedMessage
+		wantValid  bool
+		wantReason FailReason
+	}{
+		{"Valid", SignedMessage{address, message, knownSignature}, true, FailNone},
+		{"Other signer", SignedMessage{testAddress, message, knownSignature}, false, FailMismatch},
+		{"Other message", SignedMessage{address, "Hello, Bitcoin!", knownSignature}, false, FailMismatch},
+		{"Not base64", SignedMessage{address, message, "not base64!"}, false, FailDecode},
+		{"Empty message", SignedMessage{address, "", knownSignature}, false, FailDecode},
+		{"Malformed address", SignedMessage{"1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UH", message, knownSignature}, false, FailDecode},
+		{"Taproot address", SignedMessage{"bc1ppv609nr0vr25u07u95waq5lucwfm6tde4nydujnu8npg4q75mr5sxq8lt3", message, knownSignature}, false, FailDecode},
+		{"Short signature", SignedMessage{address, message, "AAAA"}, false, FailLength},
+		{"Undefined header byte", SignedMessage{address, message, withHeader(43)}, false, FailHeader},
+		{"P2WPKH header byte for P2PKH", SignedMessage{address, message, withHeader(40)}, false, FailHeader},
+		{"Unrecoverable", SignedMessage{address, message, zeroR}, false, FailRecover},
+		{"Testnet address", SignedMessage{"muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz", message, knownSignature}, false, FailNetwork},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, reason, err := VerifyWithReason(tt.msg, &chaincfg.MainNetParams)
+			if valid != tt.wantValid || reason != tt.wantReason {
+				t.Errorf("VerifyWithReason() = %v, %v (%v), want %v, %v", valid, reason, err, tt.wantValid, tt.wantReason)
+			}
+			if (err == nil) != (reason == FailNone || reason == FailMismatch) {
+				t.Errorf("VerifyWithReason() error = %v for reason %v", err, reason)
+			}
+		})
+	}
+}
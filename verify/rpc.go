@@ -0,0 +1,79 @@
+package verify
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// JSON-RPC error codes used by Bitcoin Core's verifymessage
+const (
+	RPCTypeError           = -3
+	RPCInvalidAddressOrKey = -5
+	RPCInvalidParameter    = -8
+)
+
+// verifyMessageParamsCount is the number of positional verifymessage parameters
+const verifyMessageParamsCount = 3
+
+// RPCError is a JSON-RPC error as reported by Bitcoin Core
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+// VerifyMessageRPC implements Bitcoin Core's verifymessage RPC on mainnet. It
+// takes the positional parameters [address, signature, message] and returns a
+// JSON boolean, so that it can back a compatible RPC server.
+//
+// As in Core, only P2PKH addresses are accepted and an empty message is
+// verified like any other. An invalid or non-P2PKH address or a signature that
+// is not valid base64 is an *RPCError, while a signature that does not recover
+// the address's key is reported as false.
+func VerifyMessageRPC(params []json.RawMessage) (json.RawMessage, error) {
+	if len(params) != verifyMessageParamsCount {
+		return nil, &RPCError{Code: RPCInvalidParameter, Message: "verifymessage expects 3 parameters: address, signature, message"}
+	}
+
+	var args [verifyMessageParamsCount]string
+	for i, name := range []string{"address", "signature", "message"} {
+		if err := json.Unmarshal(params[i], &args[i]); err != nil {
+			return nil, &RPCError{Code: RPCTypeError, Message: fmt.Sprintf("Expected type string for %s", name)}
+		}
+	}
+	address, signature, message := args[0], args[1], args[2]
+
+	addr, err := btcutil.DecodeAddress(address, &chaincfg.MainNetParams)
+	if err != nil || !addr.IsForNet(&chaincfg.MainNetParams) {
+		return nil, &RPCError{Code: RPCInvalidAddressOrKey, Message: "Invalid address"}
+	}
+	if _, ok := addr.(*btcutil.AddressPubKeyHash); !ok {
+		return nil, &RPCError{Code: RPCTypeError, Message: "Address does not refer to key"}
+	}
+
+	// Core verifies empty messages, so skip the ErrEmptyMessage check
+	msg := SignedMessage{Address: address, Message: message, Signature: signature}
+	options := DefaultVerifyOptions()
+	valid, err := audited(msg, &chaincfg.MainNetParams, messageDigest(message, options), func() (bool, error) {
+		return verifyDigest(address, hashBitcoinMessage(message, options), signature, &chaincfg.MainNetParams, options)
+	})
+	if err != nil {
+		var corrupt base64.CorruptInputError
+		if errors.As(err, &corrupt) {
+			return nil, &RPCError{Code: RPCTypeError, Message: "Malformed base64 encoding"}
+		}
+		LogDebug("verifymessage: %v", err)
+		valid = false
+	}
+
+	return json.Marshal(valid)
+}
@@ -0,0 +1,101 @@
+package verify
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestVerifyMessageRPC(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   string
+		want     string
+		wantCode int
+	}{
+		{
+			name:   "Valid",
+			params: `["194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "` + knownSignature + `", "Hello, Bitcoin testing!"]`,
+			want:   "true",
+		},
+		{
+			name:   "Wrong message",
+			params: `["194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "` + knownSignature + `", "Hello, Bitcoin!"]`,
+			want:   "false",
+		},
+		{
+			name:   "Wrong length",
+			params: `["194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "AAAA", "Hello, Bitcoin testing!"]`,
+			want:   "false",
+		},
+		{
+			name:     "Message and signature swapped",
+			params:   `["194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", "` + knownSignature + `"]`,
+			wantCode: RPCTypeError,
+		},
+		{
+			name:   "Empty message",
+			params: `["1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG", "IDmNXHUyfBBrunfdnHg8san0qHyhJh7fkDXRqKZuHREPFjme7atZs80PBKTVhtnnOJyoyPD4HsnUXDXsj9OLv0w=", ""]`,
+			want:   "true",
+		},
+		{
+			name:   "Empty message, wrong signature",
+			params: `["194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "` + knownSignature + `", ""]`,
+			want:   "false",
+		},
+		{
+			name:     "P2WPKH address",
+			params:   `["bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc", "Jy1zbhXyX6hBI+CYKNQXtuEBLK7LoXQ9vKb/+eKShrrhbMuPVEd7CKH61NnEyHDX144/V1jz7GnbxAF5QqfdrL0=", "Hello, Bitcoin!"]`,
+			wantCode: RPCTypeError,
+		},
+		{
+			name:     "P2SH address",
+			params:   `["3291hXxutb58vbDVVumaJpopanmfxjVpgJ", "` + knownSignature + `", "Hello, Bitcoin testing!"]`,
+			wantCode: RPCTypeError,
+		},
+		{
+			name:     "Invalid address",
+			params:   `["194vDb9xwY6XQi5bLa7FRPBewJdUqympZ8", "` + knownSignature + `", "Hello, Bitcoin testing!"]`,
+			wantCode: RPCInvalidAddressOrKey,
+		},
+		{
+			name:     "Testnet address",
+			params:   `["muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz", "` + knownSignature + `", "Hello, Bitcoin testing!"]`,
+			wantCode: RPCInvalidAddressOrKey,
+		},
+		{
+			name:     "Too few parameters",
+			params:   `["194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "` + knownSignature + `"]`,
+			wantCode: RPCInvalidParameter,
+		},
+		{
+			name:     "Non-string parameter",
+			params:   `["194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "` + knownSignature + `", 42]`,
+			wantCode: RPCTypeError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params []json.RawMessage
+			if err := json.Unmarshal([]byte(tt.params), &params); err != nil {
+				t.Fatalf("invalid test params: %v", err)
+			}
+
+			got, err := VerifyMessageRPC(params)
+			if tt.wantCode != 0 {
+				var rpcErr *RPCError
+				if !errors.As(err, &rpcErr) || rpcErr.Code != tt.wantCode {
+					t.Errorf("VerifyMessageRPC() error = %v, want code %d", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyMessageRPC() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("VerifyMessageRPC() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
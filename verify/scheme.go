@@ -0,0 +1,70 @@
+package verify
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/cryptopunkscc/bip-0137/verify/bip322"
+)
+
+// Signature schemes returned by DetectSignatureScheme
+const (
+	SchemeBIP137 = "bip137"
+	SchemeBIP322 = "bip322"
+)
+
+// ErrUnknownScheme is returned when a signature matches no supported scheme
+var ErrUnknownScheme = errors.New("unknown signature scheme")
+
+// DetectSignatureScheme inspects a base64 signature and reports whether it is a
+// 65-byte BIP-0137 recoverable signature (SchemeBIP137) or a BIP-322 "simple"
+// witness stack (SchemeBIP322). Whitespace is ignored, as in DecodeSignature,
+// so signatures wrapped across lines are detected too.
+func DetectSignatureScheme(signatureBase64 string) (scheme string, err error) {
+	if signatureBase64 == "" {
+		return "", ErrEmptySignature
+	}
+
+	sigBytes, err := base64.StdEncoding.DecodeString(stripWhitespace(signatureBase64))
+	if err != nil {
+		return "", fmt.Errorf("invalid base64 signature: %w", err)
+	}
+
+	// A recoverable signature is a header byte followed by R and S
+	if len(sigBytes) == CompactSignatureLength && sigBytes[0] >= MinHeaderByte && sigBytes[0] <= MaxHeaderByte {
+		LogDebug("Detected BIP-137 signature (header 0x%02x)", sigBytes[0])
+		return SchemeBIP137, nil
+	}
+
+	if _, err := bip322.ParseWitness(sigBytes); err == nil {
+		LogDebug("Detected BIP-322 witness signature")
+		return SchemeBIP322, nil
+	}
+
+	return "", fmt.Errorf("%w: %d byte signature", ErrUnknownScheme, len(sigBytes))
+}
+
+// VerifyAuto detects the scheme of a signature and verifies it with the matching
+// verifier: VerifyBip137SignatureWithParams for BIP-0137 signatures and
+// bip322.VerifySimple for BIP-322 "simple" signatures.
+func VerifyAuto(address, message, signatureBase64 string, params *chaincfg.Params) (bool, error) {
+	if address == "" {
+		return false, ErrEmptyAddress
+	}
+
+	scheme, err := DetectSignatureScheme(signatureBase64)
+	if err != nil {
+		return false, err
+	}
+
+	LogInfo("Verifying %s signature for %s", scheme, address)
+
+	switch scheme {
+	case SchemeBIP322:
+		return bip322.VerifySimple(address, message, stripWhitespace(signatureBase64), params)
+	default:
+		return VerifyBip137SignatureWithParams(address, message, signatureBase64, params)
+	}
+}
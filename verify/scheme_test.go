@@ -0,0 +1,130 @@
+package verify
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestDetectSignatureScheme(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature string
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "BIP-137 signature",
+			signature: knownSignature,
+			want:      SchemeBIP137,
+		},
+		{
+			name:      "BIP-322 P2WPKH witness",
+			signature: "AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtptFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI=",
+			want:      SchemeBIP322,
+		},
+		{
+			name:      "BIP-322 P2TR witness",
+			signature: "AUHd69PrJQEv+oKTfZ8l+WROBHuy9HKrbFCJu7U1iK2iiEy1vMU5EfMtjc+VSHM7aU0SDbak5IUZRVno2P5mjSafAQ==",
+			want:      SchemeBIP322,
+		},
+		{
+			name:      "BIP-137 signature wrapped at 64 characters",
+			signature: knownSignature[:64] + "\n" + knownSignature[64:] + "\n",
+			want:      SchemeBIP137,
+		},
+		{
+			name:      "Truncated data",
+			signature: "AkcwRAIg",
+			wantErr:   ErrUnknownScheme,
+		},
+		{
+			name:      "Empty signature",
+			signature: "",
+			wantErr:   ErrEmptySignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectSignatureScheme(tt.signature)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DetectSignatureScheme() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("DetectSignatureScheme() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyAuto(t *testing.T) {
+	tests := []struct {
+		name      string
+		address   string
+		message   string
+		signature string
+		wantValid bool
+		wantErr   bool
+	}{
+		{
+			name:      "BIP-137 signature",
+			address:   "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			message:   "Hello, Bitcoin testing!",
+			signature: knownSignature,
+			wantValid: true,
+		},
+		{
+			name:      "BIP-137 signature wrapped at 64 characters",
+			address:   "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			message:   "Hello, Bitcoin testing!",
+			signature: knownSignature[:64] + "\r\n" + knownSignature[64:] + "\r\n",
+			wantValid: true,
+		},
+		{
+			name:      "BIP-322 signature wrapped at 64 characters",
+			address:   "bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l",
+			message:   "Hello World",
+			signature: "AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtp\ntFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwV\nIVJqO4XCsMvViHI=\n",
+			wantValid: true,
+		},
+		{
+			name:      "BIP-322 signature",
+			address:   "bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l",
+			message:   "Hello World",
+			signature: "AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtptFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI=",
+			wantValid: true,
+		},
+		{
+			name:      "BIP-322 signature with wrong message",
+			address:   "bc1q9vza2e8x573nczrlzms0wvx3gsqjx7vavgkx0l",
+			message:   "Hello World!",
+			signature: "AkcwRAIgZRfIY3p7/DoVTty6YZbWS71bc5Vct9p9Fia83eRmw2QCICK/ENGfwLtptFluMGs2KsqoNSk89pO7F29zJLUx9a/sASECx/EgAxlkQpQ9hYjgGu6EBCPMVPwVIVJqO4XCsMvViHI=",
+			wantValid: false,
+		},
+		{
+			name:      "Unknown scheme",
+			address:   "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			message:   "Hello, Bitcoin testing!",
+			signature: "AkcwRAIg",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, err := VerifyAuto(tt.address, tt.message, tt.signature, &chaincfg.MainNetParams)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyAuto() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotValid != tt.wantValid {
+				t.Errorf("VerifyAuto() = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+}
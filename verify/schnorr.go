@@ -0,0 +1,64 @@
+package verify
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// schnorrMessageTag is the BIP-340 tag VerifySchnorr hashes messages with, the
+// same tag BIP-322 uses for its message hash
+const schnorrMessageTag = "BIP0322-signed-message"
+
+// VerifySchnorr verifies a 64-byte BIP-340 Schnorr signature made with the
+// key-path key of a P2TR address. The signed hash is the tagged hash of the
+// message with the tag "BIP0322-signed-message", without the BIP-0137 prefix,
+// and the signature is checked against the x-only output key in the address.
+// Other address types return an error wrapping ErrUnsupportedAddressType.
+func VerifySchnorr(address, message, signatureBase64 string, params *chaincfg.Params) (bool, error) {
+	if address == "" {
+		return false, ErrEmptyAddress
+	}
+	if message == "" {
+		return false, ErrEmptyMessage
+	}
+	if signatureBase64 == "" {
+		return false, ErrEmptySignature
+	}
+
+	addr, err := decodeAddress(address, params)
+	if err != nil {
+		return false, err
+	}
+	taproot, ok := addr.(*btcutil.AddressTaproot)
+	if !ok {
+		return false, fmt.Errorf("%w: Schnorr signatures require a P2TR address", ErrUnsupportedAddressType)
+	}
+
+	sigBytes, err := base64.StdEncoding.DecodeString(stripWhitespace(signatureBase64))
+	if err != nil {
+		return false, fmt.Errorf("invalid base64 signature: %w", err)
+	}
+	if len(sigBytes) != schnorr.SignatureSize {
+		return false, fmt.Errorf("%w: %d (expected %d bytes)", ErrBadLength, len(sigBytes), schnorr.SignatureSize)
+	}
+	signature, err := schnorr.ParseSignature(sigBytes)
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	outputKey, err := schnorr.ParsePubKey(taproot.ScriptAddress())
+	if err != nil {
+		return false, fmt.Errorf("invalid taproot output key: %w", err)
+	}
+
+	messageHash := chainhash.TaggedHash([]byte(schnorrMessageTag), []byte(message))
+	valid := signature.Verify(messageHash[:], outputKey)
+	LogDebug("Schnorr signature for %s valid: %t", address, valid)
+
+	return valid, nil
+}
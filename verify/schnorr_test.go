@@ -0,0 +1,73 @@
+package verify
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestVerifySchnorr(t *testing.T) {
+	// Key-path signature by the BIP-86 output key of testPrivKey
+	const (
+		taprootAddress   = "bc1p3q0jph8zln6tc2nz35fnv8tzaamdjxj4r7jmlqz2026qrz9wrw5q6vtpxc"
+		schnorrSignature = "ixsqN7y8NYaLFdanO/rmBOqJ8jFv/0S3xipnJqEEbrT3vXHMTU0WV+/oO2eVH6vuBNgWNx93/LNwtrXFS0dV0g=="
+	)
+
+	tests := []struct {
+		name      string
+		address   string
+		message   string
+		signature string
+		wantValid bool
+		wantErr   error
+	}{
+		{
+			name:      "Valid key-path signature",
+			address:   taprootAddress,
+			message:   "Hello, Taproot!",
+			signature: schnorrSignature,
+			wantValid: true,
+		},
+		{
+			name:      "Tampered message",
+			address:   taprootAddress,
+			message:   "Hello, Taproot?",
+			signature: schnorrSignature,
+			wantValid: false,
+		},
+		{
+			name:      "Other P2TR address",
+			address:   "bc1ppv609nr0vr25u07u95waq5lucwfm6tde4nydujnu8npg4q75mr5sxq8lt3",
+			message:   "Hello, Taproot!",
+			signature: schnorrSignature,
+			wantValid: false,
+		},
+		{
+			name:      "Not a P2TR address",
+			address:   testAddress,
+			message:   "Hello, Taproot!",
+			signature: schnorrSignature,
+			wantErr:   ErrUnsupportedAddressType,
+		},
+		{
+			name:      "Compact signature",
+			address:   taprootAddress,
+			message:   "Hello, Taproot!",
+			signature: knownSignature,
+			wantErr:   ErrBadLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := VerifySchnorr(tt.address, tt.message, tt.signature, &chaincfg.MainNetParams)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("VerifySchnorr() error = %v, want %v", err, tt.wantErr)
+			}
+			if valid != tt.wantValid {
+				t.Errorf("VerifySchnorr() = %v, want %v", valid, tt.wantValid)
+			}
+		})
+	}
+}
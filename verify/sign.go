@@ -0,0 +1,313 @@
+package verify
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// ErrNilPrivateKey is returned when signing is attempted without a private key
+var ErrNilPrivateKey = errors.New("nil private key")
+
+// ErrNoRecoveryID is returned when no recovery ID recovers the expected key
+var ErrNoRecoveryID = errors.New("no recovery ID recovers the public key")
+
+// SignBip137Message signs a message with the standard "Bitcoin Signed Message:\n"
+// prefix and returns the base64-encoded 65-byte compact signature. The nonce is
+// derived deterministically (RFC 6979), so the same inputs always produce the
+// same signature. The header byte is in the P2PKH range (27-34).
+func SignBip137Message(privKey *btcec.PrivateKey, message string, compressed bool) (string, error) {
+	if privKey == nil {
+		return "", ErrNilPrivateKey
+	}
+
+	messageHash := hashBitcoinMessage(message, DefaultVerifyOptions())
+	signature := ecdsa.SignCompact(privKey, messageHash, compressed)
+
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// SignBip137MessageRand is like SignBip137Message but mixes 32 bytes read from
+// rand into the RFC 6979 nonce derivation as additional entropy. Passing a
+// deterministic reader makes the output reproducible, which is useful in tests.
+func SignBip137MessageRand(privKey *btcec.PrivateKey, message string, compressed bool, rand io.Reader) (string, error) {
+	if privKey == nil {
+		return "", ErrNilPrivateKey
+	}
+	if rand == nil {
+		return "", errors.New("nil randomness source")
+	}
+
+	var extra [32]byte
+	if _, err := io.ReadFull(rand, extra[:]); err != nil {
+		return "", fmt.Errorf("failed to read randomness: %w", err)
+	}
+
+	messageHash := hashBitcoinMessage(message, DefaultVerifyOptions())
+	signature := signCompactWithEntropy(privKey, messageHash, extra[:], compressed)
+
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// SignElectrum signs a message the way Electrum does for an address of the
+// given type on mainnet, and returns that address with the signature. Electrum
+// always uses the compressed key and the compressed P2PKH header bytes (31-34),
+// even for segwit addresses, so its signatures for P2SH-P2WPKH and P2WPKH
+// addresses only verify with WithElectrumCompat.
+func SignElectrum(privKey *btcec.PrivateKey, message string, addrType AddressType) (address, signature string, err error) {
+	if privKey == nil {
+		return "", "", ErrNilPrivateKey
+	}
+
+	address, err = deriveAddress(privKey.PubKey(), true, addrType, &chaincfg.MainNetParams)
+	if err != nil {
+		return "", "", err
+	}
+
+	signature, err = SignBip137Message(privKey, message, true)
+	if err != nil {
+		return "", "", err
+	}
+
+	return address, signature, nil
+}
+
+// SignForAddressType signs a message for the address of the given type derived
+// from the compressed key on the network described by params, and returns that
+// address with the signature. Unlike SignBip137Message, the header byte is in
+// the range BIP-0137 assigns to the address type, so the signature verifies
+// against that address without any compatibility option.
+func SignForAddressType(privKey *btcec.PrivateKey, message string, addrType AddressType, params *chaincfg.Params) (address, signature string, err error) {
+	if privKey == nil {
+		return "", "", ErrNilPrivateKey
+	}
+
+	address, err = deriveAddress(privKey.PubKey(), true, addrType, params)
+	if err != nil {
+		return "", "", err
+	}
+	messageHash, err := SigningHash(message, addrType)
+	if err != nil {
+		return "", "", err
+	}
+
+	sigBytes := ecdsa.SignCompact(privKey, messageHash[:], true)
+	sigBytes[0], err = headerByteFor(int(sigBytes[0]-27)&3, true, addrType)
+	if err != nil {
+		return "", "", err
+	}
+
+	return address, base64.StdEncoding.EncodeToString(sigBytes), nil
+}
+
+// AssembleSignature builds a base64 BIP-0137 signature from the R and S values
+// of an ECDSA signature produced elsewhere, such as by an HSM that only signs
+// raw digests, together with its recovery ID (0-3). The header byte is chosen
+// from recoveryID, compressed and addrType; segwit address types require a
+// compressed key.
+func AssembleSignature(r, s *big.Int, recoveryID int, compressed bool, addrType AddressType) (string, error) {
+	if err := checkSignatureScalars(r, s); err != nil {
+		return "", err
+	}
+
+	header, err := headerByteFor(recoveryID, compressed, addrType)
+	if err != nil {
+		return "", err
+	}
+
+	signature := make([]byte, 65)
+	signature[0] = header
+	r.FillBytes(signature[1:33])
+	s.FillBytes(signature[33:65])
+
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// DERToBip137 converts a DER-encoded ECDSA signature over message, as produced
+// by signers without key recovery, into a base64 BIP-0137 signature. pubKey is
+// the signer's serialized public key; its form selects the header byte: 33
+// bytes for a compressed key (31-34), 65 bytes for an uncompressed one (27-30).
+// The recovery ID is found with ComputeRecoveryID. The header byte is in the
+// P2PKH range; use FixHeader for segwit addresses.
+func DERToBip137(der []byte, message string, pubKey []byte) (string, error) {
+	sig, err := ecdsa.ParseDERSignature(der)
+	if err != nil {
+		return "", fmt.Errorf("invalid DER signature: %w", err)
+	}
+	key, err := btcec.ParsePubKey(pubKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %w", err)
+	}
+	compressed := len(pubKey) == btcec.PubKeyBytesLenCompressed
+
+	rScalar, sScalar := sig.R(), sig.S()
+	rBytes, sBytes := rScalar.Bytes(), sScalar.Bytes()
+	r := new(big.Int).SetBytes(rBytes[:])
+	s := new(big.Int).SetBytes(sBytes[:])
+
+	var messageHash [32]byte
+	copy(messageHash[:], hashBitcoinMessage(message, DefaultVerifyOptions()))
+
+	recoveryID, err := ComputeRecoveryID(messageHash, r, s, key)
+	if err != nil {
+		return "", err
+	}
+
+	return AssembleSignature(r, s, recoveryID, compressed, AddressTypeP2PKH)
+}
+
+// ComputeRecoveryID returns the recovery ID (0-3) that recovers pubKey from
+// the signature (r, s) over messageHash. Signers that only return R and S, such
+// as HSMs, can use it to obtain the recovery ID AssembleSignature needs. It
+// returns an error wrapping ErrNoRecoveryID if no recovery ID matches.
+func ComputeRecoveryID(messageHash [32]byte, r, s *big.Int, pubKey *btcec.PublicKey) (int, error) {
+	if pubKey == nil {
+		return 0, errors.New("nil public key")
+	}
+	if err := checkSignatureScalars(r, s); err != nil {
+		return 0, err
+	}
+
+	signature := make([]byte, 65)
+	r.FillBytes(signature[1:33])
+	s.FillBytes(signature[33:65])
+
+	for recoveryID := 0; recoveryID < 4; recoveryID++ {
+		signature[0] = 31 + byte(recoveryID)
+
+		recovered, _, err := ecdsa.RecoverCompact(signature, messageHash[:])
+		if err != nil {
+			LogTrace("Recovery ID %d does not recover a key: %v", recoveryID, err)
+			continue
+		}
+		if recovered.IsEqual(pubKey) {
+			return recoveryID, nil
+		}
+	}
+
+	return 0, ErrNoRecoveryID
+}
+
+// checkSignatureScalars checks that r and s are valid ECDSA scalars
+func checkSignatureScalars(r, s *big.Int) error {
+	if r == nil || s == nil {
+		return errors.New("nil signature scalar")
+	}
+	for _, v := range []*big.Int{r, s} {
+		if v.Sign() <= 0 || v.Cmp(btcec.S256().N) >= 0 {
+			return fmt.Errorf("signature scalar %x out of range", v)
+		}
+	}
+	return nil
+}
+
+// HeaderBytesFor returns the BIP-0137 header byte for an address type and a
+// recovery ID (0-3), for a compressed key, which every address type supports.
+// It returns 0 for an unsupported address type or recovery ID.
+func HeaderBytesFor(addrType AddressType, recoveryID int) byte {
+	header, err := headerByteFor(recoveryID, true, addrType)
+	if err != nil {
+		return 0
+	}
+	return header
+}
+
+// AllHeaderBytesFor returns every header byte a signature for the address type
+// may carry, in ascending order: 27-34 for P2PKH, which allows uncompressed
+// keys, and the four compressed header bytes for segwit address types. It
+// returns nil for an unsupported address type.
+func AllHeaderBytesFor(addrType AddressType) []byte {
+	var headers []byte
+	for _, compressed := range []bool{false, true} {
+		for recoveryID := 0; recoveryID < 4; recoveryID++ {
+			if header, err := headerByteFor(recoveryID, compressed, addrType); err == nil {
+				headers = append(headers, header)
+			}
+		}
+	}
+	return headers
+}
+
+// headerByteFor returns the BIP-0137 header byte for a recovery ID, key
+// serialization and address type
+func headerByteFor(recoveryID int, compressed bool, addrType AddressType) (byte, error) {
+	if recoveryID < 0 || recoveryID > 3 {
+		return 0, fmt.Errorf("invalid recovery ID %d", recoveryID)
+	}
+
+	var base byte
+	switch addrType {
+	case AddressTypeP2PKH:
+		base = 27
+		if compressed {
+			base = 31
+		}
+	case AddressTypeP2SHP2WPKH:
+		base = 35
+	case AddressTypeP2WPKH:
+		base = 39
+	default:
+		return 0, fmt.Errorf("unsupported address type %d", addrType)
+	}
+
+	if addrType != AddressTypeP2PKH && !compressed {
+		return 0, fmt.Errorf("%s addresses require a compressed key", addrType.String())
+	}
+
+	return base + byte(recoveryID), nil
+}
+
+// signCompactWithEntropy produces a compact signature like ecdsa.SignCompact, but
+// with extra data fed into the RFC 6979 nonce generation.
+func signCompactWithEntropy(privKey *btcec.PrivateKey, hash, extra []byte, compressed bool) []byte {
+	privKeyBytes := privKey.Serialize()
+
+	var e btcec.ModNScalar
+	e.SetByteSlice(hash)
+
+	for iteration := uint32(0); ; iteration++ {
+		k := btcec.NonceRFC6979(privKeyBytes, hash, extra, nil, iteration)
+
+		// R = kG, r = R.x mod N
+		var kG btcec.JacobianPoint
+		btcec.ScalarBaseMultNonConst(k, &kG)
+		kG.ToAffine()
+
+		var xBytes [32]byte
+		kG.X.PutBytes(&xBytes)
+		var r btcec.ModNScalar
+		overflow := r.SetBytes(&xBytes)
+		if r.IsZero() {
+			continue
+		}
+
+		// Bit 0 of the recovery code is the oddness of R.y, bit 1 the x overflow
+		recoveryCode := byte(overflow<<1) | byte(kG.Y.IsOddBit())
+
+		// s = k^-1(e + dr) mod N, normalized to low-S
+		kInv := new(btcec.ModNScalar).InverseValNonConst(k)
+		s := new(btcec.ModNScalar).Mul2(&privKey.Key, &r).Add(&e).Mul(kInv)
+		if s.IsZero() {
+			continue
+		}
+		if s.IsOverHalfOrder() {
+			s.Negate()
+			recoveryCode ^= 0x01
+		}
+
+		signature := make([]byte, 65)
+		signature[0] = 27 + recoveryCode
+		if compressed {
+			signature[0] += 4
+		}
+		r.PutBytesUnchecked(signature[1:33])
+		s.PutBytesUnchecked(signature[33:65])
+		return signature
+	}
+}
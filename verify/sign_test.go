@@ -0,0 +1,329 @@
+package verify
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// testPrivKeyHex is a fixed private key used to produce test signatures.
+// Its compressed P2PKH address is testAddress.
+const (
+	testPrivKeyHex = "c28a9f80738f770d527803a566cf6fc3edf6cea586c4fc4a5223a5ad797e1ac3"
+	testAddress    = "1ExJJsNLQDNVVM1s1sdyt1o5P3GC5r32UG"
+)
+
+// testPrivKey returns the private key for testPrivKeyHex
+func testPrivKey(t testing.TB) *btcec.PrivateKey {
+	t.Helper()
+
+	keyBytes, err := hex.DecodeString(testPrivKeyHex)
+	if err != nil {
+		t.Fatalf("failed to decode test private key: %v", err)
+	}
+
+	privKey, _ := btcec.PrivKeyFromBytes(keyBytes)
+	return privKey
+}
+
+func TestSignBip137Message(t *testing.T) {
+	privKey := testPrivKey(t)
+	message := "Hello, Bitcoin testing!"
+
+	signature, err := SignBip137Message(privKey, message, true)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+
+	valid, err := VerifyBip137Signature(testAddress, message, signature)
+	if err != nil || !valid {
+		t.Errorf("VerifyBip137Signature() = %v, %v; want true, nil", valid, err)
+	}
+
+	if _, err := SignBip137Message(nil, message, true); err != ErrNilPrivateKey {
+		t.Errorf("SignBip137Message(nil) error = %v, want %v", err, ErrNilPrivateKey)
+	}
+}
+
+func TestSignBip137MessageRand(t *testing.T) {
+	privKey := testPrivKey(t)
+	message := "Hello, Bitcoin testing!"
+	fixedEntropy := bytes.Repeat([]byte{0x42}, 32)
+
+	first, err := SignBip137MessageRand(privKey, message, true, bytes.NewReader(fixedEntropy))
+	if err != nil {
+		t.Fatalf("SignBip137MessageRand() error = %v", err)
+	}
+
+	// The same entropy must reproduce the same signature
+	second, err := SignBip137MessageRand(privKey, message, true, bytes.NewReader(fixedEntropy))
+	if err != nil {
+		t.Fatalf("SignBip137MessageRand() error = %v", err)
+	}
+	if first != second {
+		t.Errorf("SignBip137MessageRand() not reproducible: %s != %s", first, second)
+	}
+
+	// Different entropy yields a different, but still valid, signature
+	other, err := SignBip137MessageRand(privKey, message, true, bytes.NewReader(bytes.Repeat([]byte{0x24}, 32)))
+	if err != nil {
+		t.Fatalf("SignBip137MessageRand() error = %v", err)
+	}
+	if other == first {
+		t.Errorf("SignBip137MessageRand() ignored the randomness source")
+	}
+
+	for _, signature := range []string{first, other} {
+		valid, err := VerifyBip137SignatureWithOptions(testAddress, message, signature, &chaincfg.MainNetParams)
+		if err != nil || !valid {
+			t.Errorf("VerifyBip137SignatureWithOptions(%s) = %v, %v; want true, nil", signature, valid, err)
+		}
+	}
+
+	// A short read must fail instead of signing with partial entropy
+	if _, err := SignBip137MessageRand(privKey, message, true, bytes.NewReader([]byte{0x01})); err == nil {
+		t.Errorf("SignBip137MessageRand() expected error for short randomness source")
+	}
+}
+
+func TestSignForAddressType(t *testing.T) {
+	message := "Signed for one address form"
+
+	tests := []struct {
+		addrType    AddressType
+		params      *chaincfg.Params
+		wantAddress string
+	}{
+		{AddressTypeP2PKH, &chaincfg.MainNetParams, testAddress},
+		{AddressTypeP2SHP2WPKH, &chaincfg.MainNetParams, "3291hXxutb58vbDVVumaJpopanmfxjVpgJ"},
+		{AddressTypeP2WPKH, &chaincfg.MainNetParams, "bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc"},
+		{AddressTypeP2PKH, &chaincfg.TestNet3Params, "muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addrType.String()+" "+tt.params.Name, func(t *testing.T) {
+			address, signature, err := SignForAddressType(testPrivKey(t), message, tt.addrType, tt.params)
+			if err != nil {
+				t.Fatalf("SignForAddressType() error = %v", err)
+			}
+			if address != tt.wantAddress {
+				t.Errorf("SignForAddressType() address = %s, want %s", address, tt.wantAddress)
+			}
+
+			header, _, err := SplitSignature(signature)
+			if err != nil {
+				t.Fatalf("SplitSignature() error = %v", err)
+			}
+			if !bytes.Contains(AllHeaderBytesFor(tt.addrType), []byte{header}) || header < 31 {
+				t.Errorf("SignForAddressType() header = %d, want a compressed %s header", header, tt.addrType)
+			}
+
+			valid, err := VerifyBip137SignatureWithOptions(address, message, signature, tt.params)
+			if err != nil || !valid {
+				t.Errorf("VerifyBip137SignatureWithOptions() = %v, %v; want true, nil", valid, err)
+			}
+		})
+	}
+
+	if _, _, err := SignForAddressType(nil, message, AddressTypeP2PKH, &chaincfg.MainNetParams); !errors.Is(err, ErrNilPrivateKey) {
+		t.Errorf("SignForAddressType() error = %v, want ErrNilPrivateKey", err)
+	}
+}
+
+func TestAssembleSignature(t *testing.T) {
+	message := "Signed by an HSM"
+
+	// Stand in for an HSM returning R, S and the recovery ID of a raw signature
+	raw := ecdsa.SignCompact(testPrivKey(t), hashBitcoinMessage(message, DefaultVerifyOptions()), true)
+	recoveryID := int(raw[0]-27) & 3
+	r := new(big.Int).SetBytes(raw[1:33])
+	s := new(big.Int).SetBytes(raw[33:65])
+
+	tests := []struct {
+		addrType AddressType
+		address  string
+	}{
+		{AddressTypeP2PKH, testAddress},
+		{AddressTypeP2WPKH, "bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc"},
+		{AddressTypeP2SHP2WPKH, "3291hXxutb58vbDVVumaJpopanmfxjVpgJ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addrType.String(), func(t *testing.T) {
+			signature, err := AssembleSignature(r, s, recoveryID, true, tt.addrType)
+			if err != nil {
+				t.Fatalf("AssembleSignature() error = %v", err)
+			}
+
+			valid, err := VerifyBip137SignatureWithOptions(tt.address, message, signature, &chaincfg.MainNetParams)
+			if err != nil || !valid {
+				t.Errorf("VerifyBip137SignatureWithOptions() = %v, %v; want true, nil", valid, err)
+			}
+		})
+	}
+
+	if _, err := AssembleSignature(r, s, recoveryID, false, AddressTypeP2WPKH); err == nil {
+		t.Errorf("AssembleSignature() expected error for an uncompressed segwit signature")
+	}
+	if _, err := AssembleSignature(r, s, 4, true, AddressTypeP2PKH); err == nil {
+		t.Errorf("AssembleSignature() expected error for recovery ID 4")
+	}
+	if _, err := AssembleSignature(r, btcec.S256().N, recoveryID, true, AddressTypeP2PKH); err == nil {
+		t.Errorf("AssembleSignature() expected error for S equal to the curve order")
+	}
+}
+
+func TestDERToBip137(t *testing.T) {
+	message := "Signed by a DER-only signer"
+	privKey := testPrivKey(t)
+	der := ecdsa.Sign(privKey, hashBitcoinMessage(message, DefaultVerifyOptions())).Serialize()
+
+	uncompressedAddress, err := deriveAddress(privKey.PubKey(), false, AddressTypeP2PKH, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("deriveAddress() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		pubKey  []byte
+		address string
+	}{
+		{"Compressed key", privKey.PubKey().SerializeCompressed(), testAddress},
+		{"Uncompressed key", privKey.PubKey().SerializeUncompressed(), uncompressedAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature, err := DERToBip137(der, message, tt.pubKey)
+			if err != nil {
+				t.Fatalf("DERToBip137() error = %v", err)
+			}
+
+			valid, err := VerifyBip137SignatureWithOptions(tt.address, message, signature, &chaincfg.MainNetParams)
+			if err != nil || !valid {
+				t.Errorf("VerifyBip137SignatureWithOptions() = %v, %v; want true, nil", valid, err)
+			}
+		})
+	}
+
+	otherKey, _ := hex.DecodeString("034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23c")
+	if _, err := DERToBip137(der, message, otherKey); !errors.Is(err, ErrNoRecoveryID) {
+		t.Errorf("DERToBip137() error = %v, want ErrNoRecoveryID", err)
+	}
+	if _, err := DERToBip137(der[:10], message, otherKey); err == nil {
+		t.Errorf("DERToBip137() expected error for a truncated DER signature")
+	}
+}
+
+func TestComputeRecoveryID(t *testing.T) {
+	// R, S and the key of knownSignature, whose header byte 0x20 encodes
+	// recovery ID 1
+	raw, _ := base64.StdEncoding.DecodeString(knownSignature)
+	r := new(big.Int).SetBytes(raw[1:33])
+	s := new(big.Int).SetBytes(raw[33:65])
+
+	pubKeyBytes, _ := hex.DecodeString("034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23c")
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		t.Fatalf("ParsePubKey() error = %v", err)
+	}
+
+	var hash [32]byte
+	copy(hash[:], hashBitcoinMessage("Hello, Bitcoin testing!", DefaultVerifyOptions()))
+
+	got, err := ComputeRecoveryID(hash, r, s, pubKey)
+	if err != nil {
+		t.Fatalf("ComputeRecoveryID() error = %v", err)
+	}
+	if got != 1 {
+		t.Errorf("ComputeRecoveryID() = %d, want 1", got)
+	}
+
+	// Another key cannot be recovered from the signature
+	if _, err := ComputeRecoveryID(hash, r, s, testPrivKey(t).PubKey()); !errors.Is(err, ErrNoRecoveryID) {
+		t.Errorf("ComputeRecoveryID() error = %v, want ErrNoRecoveryID", err)
+	}
+}
+
+func TestSignElectrum(t *testing.T) {
+	// A signature made by Electrum for a testnet P2WPKH address: the header
+	// byte is 0x1f, in the compressed P2PKH range
+	valid, err := VerifyBip137SignatureWithOptions(
+		"tb1qr97cuq4kvq7plfetmxnl6kls46xaka78n2288z",
+		"The outage comes at a time when bitcoin has been fast approaching new highs not seen since June 26, 2019.",
+		"H/bSByRH7BW1YydfZlEx9x/nt4EAx/4A691CFlK1URbPEU5tJnTIu4emuzkgZFwC0ptvKuCnyBThnyLDCqPqT10=",
+		&chaincfg.TestNet3Params, WithElectrumCompat())
+	if err != nil || !valid {
+		t.Fatalf("VerifyBip137SignatureWithOptions() for Electrum vector = %v, %v; want true, nil", valid, err)
+	}
+
+	message := "Signed like Electrum"
+	tests := []struct {
+		addrType    AddressType
+		wantAddress string
+		wantStrict  bool
+	}{
+		{AddressTypeP2PKH, testAddress, true},
+		{AddressTypeP2SHP2WPKH, "3291hXxutb58vbDVVumaJpopanmfxjVpgJ", false},
+		{AddressTypeP2WPKH, "bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addrType.String(), func(t *testing.T) {
+			address, signature, err := SignElectrum(testPrivKey(t), message, tt.addrType)
+			if err != nil {
+				t.Fatalf("SignElectrum() error = %v", err)
+			}
+			if address != tt.wantAddress {
+				t.Errorf("SignElectrum() address = %s, want %s", address, tt.wantAddress)
+			}
+
+			valid, err := VerifyBip137SignatureWithOptions(address, message, signature, &chaincfg.MainNetParams, WithElectrumCompat())
+			if err != nil || !valid {
+				t.Errorf("VerifyBip137SignatureWithOptions() in Electrum mode = %v, %v; want true, nil", valid, err)
+			}
+
+			valid, _ = VerifyBip137SignatureWithOptions(address, message, signature, &chaincfg.MainNetParams)
+			if valid != tt.wantStrict {
+				t.Errorf("VerifyBip137SignatureWithOptions() in strict mode = %v, want %v", valid, tt.wantStrict)
+			}
+		})
+	}
+}
+
+func TestHeaderBytesFor(t *testing.T) {
+	tests := []struct {
+		addrType AddressType
+		want     []byte
+		wantAll  []byte
+	}{
+		{AddressTypeP2PKH, []byte{31, 32, 33, 34}, []byte{27, 28, 29, 30, 31, 32, 33, 34}},
+		{AddressTypeP2SHP2WPKH, []byte{35, 36, 37, 38}, []byte{35, 36, 37, 38}},
+		{AddressTypeP2WPKH, []byte{39, 40, 41, 42}, []byte{39, 40, 41, 42}},
+		{AddressType(99), []byte{0, 0, 0, 0}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addrType.String(), func(t *testing.T) {
+			for recoveryID := 0; recoveryID < 4; recoveryID++ {
+				if got := HeaderBytesFor(tt.addrType, recoveryID); got != tt.want[recoveryID] {
+					t.Errorf("HeaderBytesFor(%s, %d) = %d, want %d", tt.addrType, recoveryID, got, tt.want[recoveryID])
+				}
+			}
+			if got := AllHeaderBytesFor(tt.addrType); !bytes.Equal(got, tt.wantAll) {
+				t.Errorf("AllHeaderBytesFor(%s) = %v, want %v", tt.addrType, got, tt.wantAll)
+			}
+		})
+	}
+
+	if got := HeaderBytesFor(AddressTypeP2PKH, 4); got != 0 {
+		t.Errorf("HeaderBytesFor(P2PKH, 4) = %d, want 0", got)
+	}
+}
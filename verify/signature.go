@@ -2,11 +2,13 @@ package verify
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
-	verifier "github.com/bitonicnl/verify-signed-message/pkg"
 	"github.com/btcsuite/btcd/chaincfg"
 )
 
@@ -24,23 +26,87 @@ type SignedMessage struct {
 	// Address is the Bitcoin address that allegedly signed the message
 	Address string
 
-	// Message is the content that was signed
+	// Message is the content that was signed. It is hashed exactly as given,
+	// so it must hold the signed bytes: stray characters such as a NUL or a
+	// byte order mark left by decoding are not removed, and neither is
+	// surrounding whitespace. To accept messages altered in transit, such as
+	// those trimmed by Electrum before signing, use VerifyTolerant.
 	Message string
 
 	// Signature is the base64-encoded signature
 	Signature string
 }
 
+// Validate checks that every field of m is set. The returned error joins
+// ErrEmptyAddress, ErrEmptyMessage and ErrEmptySignature for each empty field.
+func (m SignedMessage) Validate() error {
+	var errs []error
+	if m.Address == "" {
+		errs = append(errs, ErrEmptyAddress)
+	}
+	if m.Message == "" {
+		errs = append(errs, ErrEmptyMessage)
+	}
+	if m.Signature == "" {
+		errs = append(errs, ErrEmptySignature)
+	}
+	return errors.Join(errs...)
+}
+
+// Verify validates m and verifies it with VerifyBip137Signature
+func (m SignedMessage) Verify() (bool, error) {
+	if err := m.Validate(); err != nil {
+		return false, err
+	}
+	return VerifyBip137Signature(m.Address, m.Message, m.Signature)
+}
+
 // VerifyBip137Signature verifies if a message was signed by the private key
 // associated with the provided Bitcoin address according to BIP-0137.
 // It uses the Bitcoin mainnet parameters by default.
+//
+// The address argument may also hold a hex-encoded public key (33 bytes
+// compressed or 65 bytes uncompressed), for callers that store keys rather
+// than addresses; the signature is then checked with VerifyWithPubKeyHex. It
+// may also hold a BIP-21 URI as pasted from a wallet, e.g.
+// "bitcoin:1C9YVX...?label=Alice", whose address is verified. Bitcoin Cash
+// CashAddr addresses ("bitcoincash:q...", "bchtest:q...") are accepted too; their
+// network is taken from the prefix.
 func VerifyBip137Signature(address, message, signatureBase64 string) (bool, error) {
+	if isBitcoinURI(strings.TrimSpace(address)) {
+		uriAddress, _, err := ParseBitcoinURI(address)
+		if err != nil {
+			return false, err
+		}
+		LogDebug("Address argument is a bitcoin URI for %s", uriAddress)
+		address = uriAddress
+	}
+	if isCashAddr(address) {
+		legacy, params, err := cashAddrToLegacy(address)
+		if err != nil {
+			return false, err
+		}
+		LogDebug("Address argument is a CashAddr address for %s", legacy)
+		return VerifyBip137SignatureWithParams(legacy, message, signatureBase64, params)
+	}
+	if isPubKeyHex(address) {
+		LogDebug("Address argument is a public key, verifying with VerifyWithPubKeyHex")
+		return VerifyWithPubKeyHex(address, message, signatureBase64)
+	}
 	return VerifyBip137SignatureWithParams(address, message, signatureBase64, &chaincfg.MainNetParams)
 }
 
 // VerifyBip137SignatureWithParams verifies a BIP-0137 signature using the provided
 // network parameters (mainnet, testnet, etc.).
 func VerifyBip137SignatureWithParams(address, message, signatureBase64 string, params *chaincfg.Params) (bool, error) {
+	msg := SignedMessage{Address: address, Message: message, Signature: signatureBase64}
+	return audited(msg, params, messageDigest(message, DefaultVerifyOptions()), func() (bool, error) {
+		return verifyBip137SignatureWithParams(address, message, signatureBase64, params)
+	})
+}
+
+// verifyBip137SignatureWithParams implements VerifyBip137SignatureWithParams
+func verifyBip137SignatureWithParams(address, message, signatureBase64 string, params *chaincfg.Params) (bool, error) {
 	// Validate inputs
 	if address == "" {
 		return false, ErrEmptyAddress
@@ -52,33 +118,88 @@ func VerifyBip137SignatureWithParams(address, message, signatureBase64 string, p
 		return false, ErrEmptySignature
 	}
 
-	// Create a signed message struct
-	signedMessage := verifier.SignedMessage{
+	// Give a clear error for DER signatures, which the verifier would only
+	// report as malformed
+	if sigBytes, err := base64.StdEncoding.DecodeString(stripWhitespace(signatureBase64)); err == nil && isDERSignature(sigBytes) {
+		return false, ErrUnexpectedDER
+	}
+
+	// Reject address types BIP-0137 has no header bytes for
+	if err := checkAddressType(address, params); err != nil {
+		return false, err
+	}
+	if err := checkSegwitCompression(address, signatureBase64, params); err != nil {
+		return false, err
+	}
+
+	// Create a signed message struct, dropping whitespace picked up when the
+	// signature was copied, as DecodeSignature does
+	signedMessage := SignedMessage{
 		Address:   address,
 		Message:   message,
-		Signature: signatureBase64,
+		Signature: stripWhitespace(signatureBase64),
 	}
 
 	// Verify the signature using the provided network parameters
-	valid, err := verifier.VerifyWithChain(signedMessage, params)
+	valid, err := GetVerifier().Verify(signedMessage, params)
 	if err != nil {
+		// Report signatures that recover no key as ErrRecoveryFailed, as the
+		// native verifier does, rather than as the verifier's own error
+		if sigBytes, decodeErr := DecodeSignature(signedMessage.Signature); decodeErr == nil {
+			if _, _, recoverErr := recoverPublicKey(sigBytes, hashBitcoinMessage(message, DefaultVerifyOptions())); errors.Is(recoverErr, ErrRecoveryFailed) {
+				err = recoverErr
+			}
+		}
 		return false, fmt.Errorf("signature verification error: %w", err)
 	}
 
 	return valid, nil
 }
 
+// isPubKeyHex reports whether s looks like a hex-encoded compressed or
+// uncompressed public key. No address encoding has these lengths.
+func isPubKeyHex(s string) bool {
+	switch {
+	case len(s) == 66 && (strings.HasPrefix(s, "02") || strings.HasPrefix(s, "03")):
+	case len(s) == 130 && strings.HasPrefix(s, "04"):
+	default:
+		return false
+	}
+
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
 // VerifyBip137SignatureWithContext verifies a BIP-0137 signature with context support
 // for timeout and cancellation. This is the recommended approach for 2025.
+// Verification is as by VerifyBip137SignatureWithParams on mainnet.
+// Its log lines carry the request ID set with ContextWithRequestID, or a
+// generated one.
 func VerifyBip137SignatureWithContext(ctx context.Context, msg SignedMessage) (bool, error) {
-	LogInfo("Starting context-based signature verification")
+	if _, ok := RequestIDFromContext(ctx); !ok {
+		ctx = ContextWithRequestID(ctx, newRequestID())
+	}
+	logContext(ctx, LogInfo, "Starting context-based signature verification")
+
+	if err := ctx.Err(); err != nil {
+		logContext(ctx, LogError, "Context done before verification: %v", err)
+		return false, fmt.Errorf("%w: %v", ErrVerificationTimeout, err)
+	}
 
-	// Check if context has a deadline
+	// Check if context has a deadline, measured on timeSource so that tests
+	// can move the clock past it
+	var deadlineCh <-chan time.Time
 	if deadline, ok := ctx.Deadline(); ok {
-		LogDebug("Context has deadline: %s (timeout in %s)",
-			deadline.Format(time.RFC3339), time.Until(deadline))
+		remaining := deadline.Sub(timeSource.Now())
+		logContext(ctx, LogDebug, "Context has deadline: %s (timeout in %s)",
+			deadline.Format(time.RFC3339), remaining)
+		if remaining <= 0 {
+			logContext(ctx, LogError, "Context deadline passed before verification")
+			return false, fmt.Errorf("%w: %v", ErrVerificationTimeout, context.DeadlineExceeded)
+		}
+		deadlineCh = timeSource.After(remaining)
 	} else {
-		LogDebug("Context has no deadline")
+		logContext(ctx, LogDebug, "Context has no deadline")
 	}
 
 	// Create a channel to receive the verification result
@@ -88,20 +209,12 @@ func VerifyBip137SignatureWithContext(ctx context.Context, msg SignedMessage) (b
 	}, 1)
 
 	// Run verification in a goroutine
-	startTime := time.Now()
+	startTime := timeSource.Now()
 	go func() {
-		LogDebug("Starting verification goroutine")
-		// Create a signed message struct
-		signedMessage := verifier.SignedMessage{
-			Address:   msg.Address,
-			Message:   msg.Message,
-			Signature: msg.Signature,
-		}
-
-		// Verify the signature
-		valid, err := verifier.Verify(signedMessage)
-		duration := time.Since(startTime)
-		LogDebug("Verification completed in goroutine after %s", duration)
+		logContext(ctx, LogDebug, "Starting verification goroutine")
+		valid, err := VerifyBip137SignatureWithParams(msg.Address, msg.Message, msg.Signature, &chaincfg.MainNetParams)
+		duration := timeSource.Now().Sub(startTime)
+		logContext(ctx, LogDebug, "Verification completed in goroutine after %s", duration)
 
 		resultCh <- struct {
 			valid bool
@@ -113,21 +226,24 @@ func VerifyBip137SignatureWithContext(ctx context.Context, msg SignedMessage) (b
 	select {
 	case <-ctx.Done():
 		ctxErr := ctx.Err()
-		LogError("Context cancelled or timed out: %v", ctxErr)
+		logContext(ctx, LogError, "Context cancelled or timed out: %v", ctxErr)
 		return false, fmt.Errorf("%w: %v", ErrVerificationTimeout, ctxErr)
+	case <-deadlineCh:
+		logContext(ctx, LogError, "Context deadline passed during verification")
+		return false, fmt.Errorf("%w: %v", ErrVerificationTimeout, context.DeadlineExceeded)
 	case result := <-resultCh:
 		if result.err != nil {
-			LogError("Signature verification error: %v", result.err)
-			return false, fmt.Errorf("signature verification error: %w", result.err)
+			logContext(ctx, LogError, "Signature verification error: %v", result.err)
+			return false, result.err
 		}
-		LogInfo("Context-based verification result: %t", result.valid)
+		logContext(ctx, LogInfo, "Context-based verification result: %t", result.valid)
 		return result.valid, nil
 	}
 }
 
 // LogWarning logs a warning message
 func LogWarning(format string, args ...interface{}) {
-	if currentLogLevel >= LogLevelInfo {
+	if GetLogLevel() >= LogLevelInfo {
 		Logger.Printf("[WARNING] "+format, args...)
 	}
 }
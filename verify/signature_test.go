@@ -2,6 +2,9 @@ package verify
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -206,7 +209,236 @@ func TestVerifyBip137SignatureWithContext(t *testing.T) {
 	}
 }
 
+// fakeClock is a clock whose time only moves when a test sets it
+type fakeClock struct {
+	now   time.Time
+	after chan time.Time
+}
+
+func (c *fakeClock) Now() time.Time                       { return c.now }
+func (c *fakeClock) After(time.Duration) <-chan time.Time { return c.after }
+
+func TestVerifyBip137SignatureWithContextClock(t *testing.T) {
+	defer func(c clock) { timeSource = c }(timeSource)
+
+	msg := SignedMessage{Address: "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", Message: "Hello, Bitcoin testing!", Signature: knownSignature}
+	deadline := time.Now().Add(time.Hour)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	// The deadline has passed on the fake clock, though not in real time
+	timeSource = &fakeClock{now: deadline.Add(time.Second)}
+	valid, err := VerifyBip137SignatureWithContext(ctx, msg)
+	if valid || !errors.Is(err, ErrVerificationTimeout) {
+		t.Errorf("VerifyBip137SignatureWithContext() = %v, %v, want false, ErrVerificationTimeout", valid, err)
+	}
+
+	// Before the deadline, with a timer that never fires, verification completes
+	timeSource = &fakeClock{now: deadline.Add(-time.Second)}
+	valid, err = VerifyBip137SignatureWithContext(ctx, msg)
+	if err != nil || !valid {
+		t.Errorf("VerifyBip137SignatureWithContext() = %v, %v, want true, nil", valid, err)
+	}
+}
+
 // Helper function to check if an error is a timeout error
 func isTimeoutError(err error) bool {
 	return err != nil && err.Error() != "" && (err.Error()[:len("signature verification timed out")] == "signature verification timed out")
 }
+
+func TestVerifyBip137SignatureWithParamsTestnet(t *testing.T) {
+	tests := []struct {
+		name      string
+		address   string
+		message   string
+		signature string
+		wantValid bool
+	}{
+		{
+			name:      "Testnet P2PKH signature",
+			address:   "muUFbvTKDEokGTVUjScMhw1QF2rtv5hxCz",
+			message:   "Hello, Bitcoin testnet!",
+			signature: "H1iW5hS8TmYTjWSKywZrcYRfPiXT/LhoY+uqCYa64XqTP1oFtFTALrfpB1giJ3ks5z6Eax9xVogwNrlOnR5s2SI=",
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, err := VerifyBip137SignatureWithParams(tt.address, tt.message, tt.signature, &chaincfg.TestNet3Params)
+			if err != nil {
+				t.Errorf("VerifyBip137SignatureWithParams() error = %v", err)
+				return
+			}
+			if gotValid != tt.wantValid {
+				t.Errorf("VerifyBip137SignatureWithParams() = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestVerifyBip137SignatureUnsupportedAddressType(t *testing.T) {
+	taproot := "bc1ppv609nr0vr25u07u95waq5lucwfm6tde4nydujnu8npg4q75mr5sxq8lt3"
+
+	_, err := VerifyBip137Signature(taproot, "Hello, Bitcoin testing!", knownSignature)
+	if !errors.Is(err, ErrUnsupportedAddressType) {
+		t.Fatalf("VerifyBip137Signature() error = %v, want ErrUnsupportedAddressType", err)
+	}
+	if !strings.Contains(err.Error(), "P2TR") {
+		t.Errorf("VerifyBip137Signature() error = %v, want it to name P2TR", err)
+	}
+
+	_, err = VerifyBip137SignatureWithOptions(taproot, "Hello, Bitcoin testing!", knownSignature, &chaincfg.MainNetParams)
+	if !errors.Is(err, ErrUnsupportedAddressType) {
+		t.Errorf("VerifyBip137SignatureWithOptions() error = %v, want ErrUnsupportedAddressType", err)
+	}
+}
+
+func TestVerifyBip137SignaturePubKeyHex(t *testing.T) {
+	tests := []struct {
+		name      string
+		pubKeyHex string
+		message   string
+		wantValid bool
+	}{
+		{
+			name:      "Compressed public key",
+			pubKeyHex: "034fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23c",
+			message:   "Hello, Bitcoin testing!",
+			wantValid: true,
+		},
+		{
+			name:      "Uncompressed public key",
+			pubKeyHex: "044fafbb0673368ea3dcc7003a753c51bf240471c3a1b811491ba9f3480091e23cf1814f2c277aa4c700dfc671b7f8e86197daaa2cb93b4dc782a378633f78f869",
+			message:   "Hello, Bitcoin testing!",
+			wantValid: true,
+		},
+		{
+			name:      "Other public key",
+			pubKeyHex: "033d5c2875c9bd116875a71a5db64cffcb13396b163d039b1d9327824891804334",
+			message:   "Hello, Bitcoin testing!",
+			wantValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValid, _ := VerifyBip137Signature(tt.pubKeyHex, tt.message, knownSignature)
+			if gotValid != tt.wantValid {
+				t.Errorf("VerifyBip137Signature() = %v, want %v", gotValid, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestSignedMessageValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      SignedMessage
+		wantErrs []error
+	}{
+		{"Empty message", SignedMessage{}, []error{ErrEmptyAddress, ErrEmptyMessage, ErrEmptySignature}},
+		{"Missing signature", SignedMessage{Address: "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", Message: "Hello, Bitcoin testing!"}, []error{ErrEmptySignature}},
+		{"Complete", SignedMessage{Address: "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", Message: "Hello, Bitcoin testing!", Signature: knownSignature}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.Validate()
+			if (err != nil) != (tt.wantErrs != nil) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.wantErrs)
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("Validate() error = %v, want it to include %v", err, want)
+				}
+			}
+
+			// Verify reports the same errors before verifying
+			valid, err := tt.msg.Verify()
+			if tt.wantErrs != nil && (valid || !errors.Is(err, tt.wantErrs[0])) {
+				t.Errorf("Verify() = %v, %v, want false, %v", valid, err, tt.wantErrs[0])
+			}
+			if tt.wantErrs == nil && (!valid || err != nil) {
+				t.Errorf("Verify() = %v, %v, want true, nil", valid, err)
+			}
+		})
+	}
+}
+
+func BenchmarkVerifyParallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			valid, err := VerifyBip137Signature("194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9", "Hello, Bitcoin testing!", knownSignature)
+			if err != nil || !valid {
+				b.Errorf("VerifyBip137Signature() = %v, %v, want true, nil", valid, err)
+				return
+			}
+		}
+	})
+}
+
+func TestVerifyExactMessageBytes(t *testing.T) {
+	// Stray characters that survive JSON unmarshaling, and surrounding
+	// whitespace. No verification path may drop them.
+	tests := []struct {
+		name     string
+		message  string
+		stripped string
+	}{
+		{"Leading NUL", "\u0000Hello, Bitcoin!", "Hello, Bitcoin!"},
+		{"Trailing NUL", "Hello, Bitcoin!\u0000", "Hello, Bitcoin!"},
+		{"Leading BOM", "\uFEFFHello, Bitcoin!", "Hello, Bitcoin!"},
+		{"Trailing newline", "Hello, Bitcoin!\n", "Hello, Bitcoin!"},
+		{"Leading spaces", "  Hello, Bitcoin!", "Hello, Bitcoin!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := SignBip137Message(testPrivKey(t), tt.message, true)
+			if err != nil {
+				t.Fatalf("SignBip137Message() error = %v", err)
+			}
+			strippedSig, err := SignBip137Message(testPrivKey(t), tt.stripped, true)
+			if err != nil {
+				t.Fatalf("SignBip137Message() error = %v", err)
+			}
+
+			// Decoded from JSON, the message keeps its exact bytes
+			encoded, _ := json.Marshal(SignedMessage{Address: testAddress, Message: tt.message, Signature: sig})
+			var msg SignedMessage
+			if err := json.Unmarshal(encoded, &msg); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if valid, err := msg.Verify(); err != nil || !valid {
+				t.Errorf("SignedMessage.Verify() = %v, %v, want true, nil", valid, err)
+			}
+
+			if valid, err := VerifyBip137SignatureWithOptions(testAddress, tt.message, sig, &chaincfg.MainNetParams); err != nil || !valid {
+				t.Errorf("VerifyBip137SignatureWithOptions() = %v, %v, want true, nil", valid, err)
+			}
+			if valid, err := VerifyBip137SignatureWithParams(testAddress, tt.message, sig, &chaincfg.MainNetParams); err != nil || !valid {
+				t.Errorf("VerifyBip137SignatureWithParams() = %v, %v, want true, nil", valid, err)
+			}
+			if valid, err := VerifyBip137SignatureWithContext(context.Background(), SignedMessage{Address: testAddress, Message: tt.message, Signature: sig}); err != nil || !valid {
+				t.Errorf("VerifyBip137SignatureWithContext() = %v, %v, want true, nil", valid, err)
+			}
+
+			// Neither form verifies the other's signature
+			for _, c := range []struct{ message, sig string }{{tt.stripped, sig}, {tt.message, strippedSig}} {
+				if valid, _ := VerifyBip137Signature(testAddress, c.message, c.sig); valid {
+					t.Errorf("VerifyBip137Signature(%q) = true, want false", c.message)
+				}
+				if valid, _ := VerifyBip137SignatureWithParams(testAddress, c.message, c.sig, &chaincfg.MainNetParams); valid {
+					t.Errorf("VerifyBip137SignatureWithParams(%q) = true, want false", c.message)
+				}
+				if valid, _ := VerifyBip137SignatureWithContext(context.Background(), SignedMessage{Address: testAddress, Message: c.message, Signature: c.sig}); valid {
+					t.Errorf("VerifyBip137SignatureWithContext(%q) = true, want false", c.message)
+				}
+				if valid, _ := VerifyBip137SignatureWithOptions(testAddress, c.message, c.sig, &chaincfg.MainNetParams); valid {
+					t.Errorf("VerifyBip137SignatureWithOptions(%q) = true, want false", c.message)
+				}
+			}
+		})
+	}
+}
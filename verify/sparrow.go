@@ -0,0 +1,82 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Markers of the armored layout Sparrow uses for exported signed messages
+const (
+	sparrowBeginMessage   = "-----BEGIN BITCOIN SIGNED MESSAGE-----"
+	sparrowBeginSignature = "-----BEGIN BITCOIN SIGNATURE-----"
+	sparrowEndSignature   = "-----END BITCOIN SIGNATURE-----"
+)
+
+// ErrInvalidExport is returned when a signed message export cannot be parsed
+var ErrInvalidExport = errors.New("invalid signed message export")
+
+// ParseSparrowExport parses a signed message exported by Sparrow wallet:
+//
+//	-----BEGIN BITCOIN SIGNED MESSAGE-----
+//	<message>
+//	-----BEGIN BITCOIN SIGNATURE-----
+//	<address>
+//	<base64 signature>
+//	-----END BITCOIN SIGNATURE-----
+//
+// The message is every line between the first two markers, joined with "\n";
+// CRLF line endings are accepted. The address line may carry an "Address: "
+// prefix and be preceded by a "Version: " line, as in Electrum's variant of the
+// layout. Text outside the markers is ignored.
+func ParseSparrowExport(text string) (SignedMessage, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	begin := indexOfLine(lines, sparrowBeginMessage, 0)
+	if begin < 0 {
+		return SignedMessage{}, fmt.Errorf("%w: missing %s", ErrInvalidExport, sparrowBeginMessage)
+	}
+	sigBegin := indexOfLine(lines, sparrowBeginSignature, begin+1)
+	if sigBegin < 0 {
+		return SignedMessage{}, fmt.Errorf("%w: missing %s", ErrInvalidExport, sparrowBeginSignature)
+	}
+	end := indexOfLine(lines, sparrowEndSignature, sigBegin+1)
+	if end < 0 {
+		return SignedMessage{}, fmt.Errorf("%w: missing %s", ErrInvalidExport, sparrowEndSignature)
+	}
+
+	// The address comes first in the signature block, followed by the signature
+	var fields []string
+	for _, line := range lines[sigBegin+1 : end] {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "Version:") {
+			continue
+		}
+		fields = append(fields, strings.TrimSpace(strings.TrimPrefix(line, "Address:")))
+	}
+	if len(fields) < 2 {
+		return SignedMessage{}, fmt.Errorf("%w: expected an address and a signature", ErrInvalidExport)
+	}
+
+	msg := SignedMessage{
+		Address:   fields[0],
+		Message:   strings.Join(lines[begin+1:sigBegin], "\n"),
+		Signature: strings.Join(fields[1:], ""),
+	}
+	if msg.Message == "" {
+		return SignedMessage{}, fmt.Errorf("%w: %w", ErrInvalidExport, ErrEmptyMessage)
+	}
+
+	return msg, nil
+}
+
+// indexOfLine returns the index of the first line from start that equals want
+// once surrounding whitespace is trimmed, or -1
+func indexOfLine(lines []string, want string, start int) int {
+	for i := start; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == want {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,88 @@
+package verify
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSparrowExport(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    SignedMessage
+		wantErr error
+	}{
+		{
+			name: "Sparrow export",
+			text: "-----BEGIN BITCOIN SIGNED MESSAGE-----\n" +
+				"Hello, Bitcoin testing!\n" +
+				"-----BEGIN BITCOIN SIGNATURE-----\n" +
+				"194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9\n" +
+				knownSignature + "\n" +
+				"-----END BITCOIN SIGNATURE-----\n",
+			want: SignedMessage{
+				Address:   "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+				Message:   "Hello, Bitcoin testing!",
+				Signature: knownSignature,
+			},
+		},
+		{
+			name: "CRLF with version and address headers",
+			text: "-----BEGIN BITCOIN SIGNED MESSAGE-----\r\n" +
+				"first line\r\nsecond line\r\n" +
+				"-----BEGIN BITCOIN SIGNATURE-----\r\n" +
+				"Version: Bitcoin-qt (1.0)\r\n" +
+				"Address: 194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9\r\n\r\n" +
+				knownSignature + "\r\n" +
+				"-----END BITCOIN SIGNATURE-----",
+			want: SignedMessage{
+				Address:   "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+				Message:   "first line\nsecond line",
+				Signature: knownSignature,
+			},
+		},
+		{
+			name: "Missing signature",
+			text: "-----BEGIN BITCOIN SIGNED MESSAGE-----\n" +
+				"Hello, Bitcoin testing!\n" +
+				"-----BEGIN BITCOIN SIGNATURE-----\n" +
+				"194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9\n" +
+				"-----END BITCOIN SIGNATURE-----\n",
+			wantErr: ErrInvalidExport,
+		},
+		{
+			name:    "Not an export",
+			text:    "Hello, Bitcoin testing!",
+			wantErr: ErrInvalidExport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSparrowExport(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseSparrowExport() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSparrowExport() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSparrowExportVerifies(t *testing.T) {
+	msg, err := ParseSparrowExport("-----BEGIN BITCOIN SIGNED MESSAGE-----\n" +
+		"Hello, Bitcoin testing!\n" +
+		"-----BEGIN BITCOIN SIGNATURE-----\n" +
+		"194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9\n" +
+		knownSignature + "\n" +
+		"-----END BITCOIN SIGNATURE-----\n")
+	if err != nil {
+		t.Fatalf("ParseSparrowExport() error = %v", err)
+	}
+
+	valid, err := VerifyBip137Signature(msg.Address, msg.Message, msg.Signature)
+	if err != nil || !valid {
+		t.Errorf("VerifyBip137Signature() = %v, %v, want true, nil", valid, err)
+	}
+}
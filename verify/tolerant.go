@@ -0,0 +1,94 @@
+package verify
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Message transformations tried by VerifyTolerant, in order
+const (
+	TransformNone            = "none"
+	TransformTrimSpace       = "trim-space"
+	TransformCRLFToLF        = "crlf-to-lf"
+	TransformLFToCRLF        = "lf-to-crlf"
+	TransformTrailingNewline = "trailing-newline"
+	TransformNFC             = "nfc"
+)
+
+// messageTransform rewrites a message before it is verified
+type messageTransform struct {
+	name  string
+	apply func(string) string
+}
+
+// tolerantTransforms lists the transformations tried by VerifyTolerant
+var tolerantTransforms = []messageTransform{
+	{TransformNone, func(m string) string { return m }},
+	{TransformTrimSpace, strings.TrimSpace},
+	{TransformCRLFToLF, func(m string) string { return strings.ReplaceAll(m, "\r\n", "\n") }},
+	{TransformLFToCRLF, func(m string) string {
+		return strings.ReplaceAll(strings.ReplaceAll(m, "\r\n", "\n"), "\n", "\r\n")
+	}},
+	{TransformTrailingNewline, func(m string) string { return m + "\n" }},
+	{TransformNFC, norm.NFC.String},
+}
+
+// VerifyTolerant verifies a signature over a message that may have been altered
+// in transit, as happens when messages are copied between web forms, emails and
+// editors. It tries the message as given, then with surrounding whitespace
+// trimmed, with its line endings converted either way, with a trailing newline
+// and in Unicode NFC, and returns the name of the first transformation (one of
+// the Transform constants) under which the signature verifies, together with
+// the transformed message. Those are the exact bytes that were signed, so they
+// are what should be stored to verify the message again later.
+//
+// Verification otherwise behaves like VerifyBip137SignatureWithOptions with
+// default options. Errors that do not depend on the message, such as a
+// malformed address or signature, are returned immediately.
+func VerifyTolerant(address, message, signatureBase64 string, params *chaincfg.Params) (transformation string, matched []byte, ok bool, err error) {
+	options := DefaultVerifyOptions()
+	msg := SignedMessage{Address: address, Message: message, Signature: signatureBase64}
+	digest := func() ([]byte, error) {
+		// Recover over the bytes that verified, if any
+		if ok {
+			return hashMessage(string(matched), options)
+		}
+		return hashMessage(message, options)
+	}
+	_, err = audited(msg, params, digest, func() (bool, error) {
+		var verifyErr error
+		transformation, matched, ok, verifyErr = verifyTolerant(address, message, signatureBase64, params, options)
+		return ok, verifyErr
+	})
+	return transformation, matched, ok, err
+}
+
+// verifyTolerant implements VerifyTolerant
+func verifyTolerant(address, message, signatureBase64 string, params *chaincfg.Params, options VerifyOptions) (string, []byte, bool, error) {
+	if message == "" {
+		return "", nil, false, ErrEmptyMessage
+	}
+
+	tried := make(map[string]bool, len(tolerantTransforms))
+	for _, transform := range tolerantTransforms {
+		candidate := transform.apply(message)
+		if candidate == "" || tried[candidate] {
+			continue
+		}
+		tried[candidate] = true
+
+		valid, err := verifyWithOptions(address, candidate, signatureBase64, params, options)
+		if err != nil && !errors.Is(err, ErrRecoveryFailed) {
+			return "", nil, false, err
+		}
+		if valid {
+			LogDebug("Signature verified with message transformation %s", transform.name)
+			return transform.name, []byte(candidate), true, nil
+		}
+	}
+
+	return "", nil, false, nil
+}
@@ -0,0 +1,80 @@
+package verify
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestVerifyTolerant(t *testing.T) {
+	tests := []struct {
+		name      string
+		address   string
+		message   string
+		signature string
+		want      string
+		wantBytes string
+		wantOK    bool
+	}{
+		{
+			name:      "Unchanged message",
+			address:   "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			message:   "Hello, Bitcoin testing!",
+			signature: knownSignature,
+			want:      TransformNone,
+			wantBytes: "Hello, Bitcoin testing!",
+			wantOK:    true,
+		},
+		{
+			name:      "Surrounding whitespace",
+			address:   "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			message:   "  Hello, Bitcoin testing!\r\n",
+			signature: knownSignature,
+			want:      TransformTrimSpace,
+			wantBytes: "Hello, Bitcoin testing!",
+			wantOK:    true,
+		},
+		{
+			// Signed over "Café" with a precomposed é, given with a combining accent
+			name:      "Decomposed accent",
+			address:   testAddress,
+			message:   "Cafe\u0301 au lait",
+			signature: "IHE1HDSBaFB2SahZBIYDRloLOQuRcUaxoDJmKjP2eK0AKIzlPpji2RLFJb0jWjPYX1xibEidxuQy8xQUF3ENqWg=",
+			want:      TransformNFC,
+			wantBytes: "Caf\u00e9 au lait",
+			wantOK:    true,
+		},
+		{
+			name:      "Signed with a trailing newline",
+			address:   testAddress,
+			message:   "Signed on a web wallet",
+			signature: "H+cS2LCWkXq/ybUSPDd9QsgDQCnN6XyJ1Wz7b3bRcexYNZhNCU+imT8OqbLz0ukWpyTI8jdZUoq+uqUM+ScQnBY=",
+			want:      TransformTrailingNewline,
+			wantBytes: "Signed on a web wallet\n",
+			wantOK:    true,
+		},
+		{
+			name:      "Different message",
+			address:   "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			message:   "Hello, Bitcoin!",
+			signature: knownSignature,
+			want:      "",
+			wantOK:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotBytes, ok, err := VerifyTolerant(tt.address, tt.message, tt.signature, &chaincfg.MainNetParams)
+			if err != nil {
+				t.Fatalf("VerifyTolerant() error = %v", err)
+			}
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("VerifyTolerant() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+			if string(gotBytes) != tt.wantBytes {
+				t.Errorf("VerifyTolerant() matched = %q, want %q", gotBytes, tt.wantBytes)
+			}
+		})
+	}
+}
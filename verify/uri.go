@@ -0,0 +1,41 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// bitcoinURIScheme is the BIP-21 URI scheme, matched case-insensitively
+const bitcoinURIScheme = "bitcoin:"
+
+// ErrInvalidURI is returned when a BIP-21 bitcoin: URI cannot be parsed
+var ErrInvalidURI = errors.New("invalid bitcoin URI")
+
+// ParseBitcoinURI extracts the address and query parameters (label, amount,
+// etc.) from a BIP-21 URI such as "bitcoin:1C9YVX...?label=Alice". The address
+// is returned as written; it is not validated.
+func ParseBitcoinURI(uri string) (address string, params url.Values, err error) {
+	uri = strings.TrimSpace(uri)
+	if !isBitcoinURI(uri) {
+		return "", nil, fmt.Errorf("%w: missing %q scheme", ErrInvalidURI, bitcoinURIScheme)
+	}
+
+	address, query, _ := strings.Cut(uri[len(bitcoinURIScheme):], "?")
+	if address == "" {
+		return "", nil, fmt.Errorf("%w: missing address", ErrInvalidURI)
+	}
+
+	params, err = url.ParseQuery(query)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrInvalidURI, err)
+	}
+
+	return address, params, nil
+}
+
+// isBitcoinURI reports whether s starts with the bitcoin: scheme
+func isBitcoinURI(s string) bool {
+	return len(s) >= len(bitcoinURIScheme) && strings.EqualFold(s[:len(bitcoinURIScheme)], bitcoinURIScheme)
+}
@@ -0,0 +1,62 @@
+package verify
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseBitcoinURI(t *testing.T) {
+	tests := []struct {
+		name        string
+		uri         string
+		wantAddress string
+		wantLabel   string
+		wantErr     error
+	}{
+		{
+			name:        "Full BIP-21 URI",
+			uri:         "bitcoin:194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9?amount=0.01&label=Luke-Jr&message=Donation%20for%20project%20xyz",
+			wantAddress: "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			wantLabel:   "Luke-Jr",
+		},
+		{
+			name:        "Uppercase scheme without parameters",
+			uri:         "BITCOIN:BC1QTPL26UTZHQURDEQHXE7S269HQZTE504KQXAVAE",
+			wantAddress: "BC1QTPL26UTZHQURDEQHXE7S269HQZTE504KQXAVAE",
+		},
+		{
+			name:    "Missing scheme",
+			uri:     "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+			wantErr: ErrInvalidURI,
+		},
+		{
+			name:    "Missing address",
+			uri:     "bitcoin:?label=Luke-Jr",
+			wantErr: ErrInvalidURI,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotAddress, gotParams, err := ParseBitcoinURI(tt.uri)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseBitcoinURI() error = %v, want %v", err, tt.wantErr)
+			}
+			if gotAddress != tt.wantAddress {
+				t.Errorf("ParseBitcoinURI() address = %q, want %q", gotAddress, tt.wantAddress)
+			}
+			if got := gotParams.Get("label"); got != tt.wantLabel {
+				t.Errorf("ParseBitcoinURI() label = %q, want %q", got, tt.wantLabel)
+			}
+		})
+	}
+}
+
+func TestVerifyBip137SignatureURI(t *testing.T) {
+	uri := "bitcoin:194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9?amount=0.01&label=Luke-Jr"
+
+	valid, err := VerifyBip137Signature(uri, "Hello, Bitcoin testing!", knownSignature)
+	if err != nil || !valid {
+		t.Errorf("VerifyBip137Signature() = %v, %v, want true, nil", valid, err)
+	}
+}
@@ -0,0 +1,97 @@
+package verify
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+
+	verifier "github.com/bitonicnl/verify-signed-message/pkg"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// ErrNoVerifier is returned when verification is delegated to a nil VerifierFunc
+var ErrNoVerifier = errors.New("no verifier configured")
+
+// Verifier verifies a signed message against the given network parameters
+type Verifier interface {
+	Verify(msg SignedMessage, params *chaincfg.Params) (bool, error)
+}
+
+// VerifierFunc adapts an ordinary function to the Verifier interface
+type VerifierFunc func(msg SignedMessage, params *chaincfg.Params) (bool, error)
+
+// Verify calls f(msg, params), or returns ErrNoVerifier if f is nil
+func (f VerifierFunc) Verify(msg SignedMessage, params *chaincfg.Params) (bool, error) {
+	if f == nil {
+		return false, ErrNoVerifier
+	}
+	return f(msg, params)
+}
+
+// externalVerifier delegates to the bitonicnl/verify-signed-message package
+type externalVerifier struct{}
+
+// Verify implements Verifier
+func (externalVerifier) Verify(msg SignedMessage, params *chaincfg.Params) (bool, error) {
+	valid, err := verifier.VerifyWithChain(verifier.SignedMessage{
+		Address:   msg.Address,
+		Message:   msg.Message,
+		Signature: msg.Signature,
+	}, params)
+	if err != nil || !valid {
+		return valid, err
+	}
+
+	// The external package also accepts a signature over the message with
+	// surrounding whitespace trimmed. A signature cannot verify both forms, so
+	// if the trimmed form verifies it is what was signed, not msg.Message.
+	trimmed := strings.TrimSpace(msg.Message)
+	if trimmed == msg.Message || trimmed == "" {
+		return true, nil
+	}
+	trimmedValid, err := verifier.VerifyWithChain(verifier.SignedMessage{
+		Address:   msg.Address,
+		Message:   trimmed,
+		Signature: msg.Signature,
+	}, params)
+	return err != nil || !trimmedValid, nil
+}
+
+// nativeVerifier uses the package's own recovery code and honors VerifyOptions
+type nativeVerifier struct {
+	opts VerifyOptions
+}
+
+// Verify implements Verifier
+func (v nativeVerifier) Verify(msg SignedMessage, params *chaincfg.Params) (bool, error) {
+	return verifyWithOptions(msg.Address, msg.Message, msg.Signature, params, v.opts)
+}
+
+// NewVerifier returns a Verifier backed by the package's own BIP-0137 recovery
+// code, configured by opts. See VerifyBip137SignatureWithOptions.
+func NewVerifier(opts ...Option) Verifier {
+	return nativeVerifier{opts: newVerifyOptions(opts...)}
+}
+
+// Current verifier used by VerifyBip137SignatureWithParams. It is accessed
+// atomically; nil selects the default external implementation.
+var currentVerifier atomic.Pointer[Verifier]
+
+// SetVerifier sets the verifier used by VerifyBip137SignatureWithParams and the
+// functions built on it. A nil verifier restores the default external verifier.
+// It is safe to call while verifications run on other goroutines.
+func SetVerifier(v Verifier) {
+	if v == nil {
+		currentVerifier.Store(nil)
+		return
+	}
+	currentVerifier.Store(&v)
+}
+
+// GetVerifier returns the current verifier
+func GetVerifier() Verifier {
+	if v := currentVerifier.Load(); v != nil {
+		return *v
+	}
+	return externalVerifier{}
+}
@@ -0,0 +1,17 @@
+// Package wasm exposes the verify package to JavaScript when compiled with
+// GOOS=js GOARCH=wasm. On other platforms the package is empty.
+//
+// A minimal program registers the functions and blocks so they stay callable:
+//
+//	func main() {
+//		wasm.Register()
+//		select {}
+//	}
+//
+// Once loaded, the global object provides:
+//
+//	verifyBip137(address, message, signature[, network]) -> {valid, error}
+//	recoverAddress(message, signature[, network]) -> {address, error}
+//
+// network is one of "mainnet" (default), "testnet3", "regtest" or "signet".
+package wasm
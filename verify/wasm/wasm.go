@@ -0,0 +1,91 @@
+//go:build js && wasm
+
+package wasm
+
+import (
+	"fmt"
+	"syscall/js"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/cryptopunkscc/bip-0137/verify"
+)
+
+// networks maps the names accepted from JavaScript to network parameters
+var networks = map[string]*chaincfg.Params{
+	chaincfg.MainNetParams.Name:       &chaincfg.MainNetParams,
+	chaincfg.TestNet3Params.Name:      &chaincfg.TestNet3Params,
+	chaincfg.RegressionNetParams.Name: &chaincfg.RegressionNetParams,
+	chaincfg.SigNetParams.Name:        &chaincfg.SigNetParams,
+}
+
+// Register sets verifyBip137 and recoverAddress on the JavaScript global object
+func Register() {
+	js.Global().Set("verifyBip137", js.FuncOf(verifyBip137))
+	js.Global().Set("recoverAddress", js.FuncOf(recoverAddress))
+}
+
+// verifyBip137 wraps verify.VerifyBip137SignatureWithParams
+func verifyBip137(_ js.Value, args []js.Value) any {
+	if len(args) < 3 {
+		return result("valid", false, fmt.Errorf("verifyBip137 expects address, message and signature"))
+	}
+
+	params, err := networkArg(args, 3)
+	if err != nil {
+		return result("valid", false, err)
+	}
+
+	valid, err := verify.VerifyBip137SignatureWithParams(args[0].String(), args[1].String(), args[2].String(), params)
+	return result("valid", valid, err)
+}
+
+// recoverAddress returns the address of the type selected by the signature's
+// header byte for the recovered key
+func recoverAddress(_ js.Value, args []js.Value) any {
+	if len(args) < 2 {
+		return result("address", "", fmt.Errorf("recoverAddress expects message and signature"))
+	}
+
+	params, err := networkArg(args, 2)
+	if err != nil {
+		return result("address", "", err)
+	}
+
+	message, signature := args[0].String(), args[1].String()
+	header, _, err := verify.SplitSignature(signature)
+	if err != nil {
+		return result("address", "", err)
+	}
+	pubKey, compressed, err := verify.RecoverPubKey(message, signature)
+	if err != nil {
+		return result("address", "", err)
+	}
+
+	address, err := verify.AddressForHeader(pubKey, compressed, header, params)
+	if err != nil {
+		return result("address", "", fmt.Errorf("no address can be derived for header byte %d: %w", header, err))
+	}
+	return result("address", address, nil)
+}
+
+// networkArg returns the network named by args[i], defaulting to mainnet
+func networkArg(args []js.Value, i int) (*chaincfg.Params, error) {
+	if len(args) <= i || args[i].IsUndefined() || args[i].IsNull() {
+		return &chaincfg.MainNetParams, nil
+	}
+
+	params, ok := networks[args[i].String()]
+	if !ok {
+		return nil, fmt.Errorf("unknown network %q", args[i].String())
+	}
+	return params, nil
+}
+
+// result builds the object returned to JavaScript
+func result(key string, value any, err error) map[string]any {
+	out := map[string]any{key: value, "error": nil}
+	if err != nil {
+		out["error"] = err.Error()
+	}
+	return out
+}
@@ -0,0 +1,39 @@
+//go:build js && wasm
+
+package wasm
+
+import (
+	"syscall/js"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	Register()
+
+	got := js.Global().Call("verifyBip137",
+		"194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9",
+		"Hello, Bitcoin testing!",
+		"IOeVH/0KqgmS3XKwqCJiwlcHonwxKMQN6fbOW5UsXSDZB4EGCVTXx6c+ZU/Ae5qO94MSBZn2aPOiUsupRIwBaAU=")
+	if !got.Get("error").IsNull() || !got.Get("valid").Bool() {
+		t.Errorf("verifyBip137() = %v, %v; want true, null", got.Get("valid"), got.Get("error"))
+	}
+
+	got = js.Global().Call("recoverAddress",
+		"Hello, Bitcoin testing!",
+		"IOeVH/0KqgmS3XKwqCJiwlcHonwxKMQN6fbOW5UsXSDZB4EGCVTXx6c+ZU/Ae5qO94MSBZn2aPOiUsupRIwBaAU=")
+	if address := got.Get("address").String(); address != "194vDb9xwY6XQi5bLa7FRPBewJdUqympZ9" {
+		t.Errorf("recoverAddress() = %s, error %v", address, got.Get("error"))
+	}
+
+	got = js.Global().Call("recoverAddress",
+		"Hello, Bitcoin!",
+		"Jy1zbhXyX6hBI+CYKNQXtuEBLK7LoXQ9vKb/+eKShrrhbMuPVEd7CKH61NnEyHDX144/V1jz7GnbxAF5QqfdrL0=")
+	if address := got.Get("address").String(); address != "bc1qny80vrtrkk6evjsuy2pqvxh52y37j07tqcetcc" {
+		t.Errorf("recoverAddress() = %s, error %v", address, got.Get("error"))
+	}
+
+	got = js.Global().Call("verifyBip137", "a", "b", "c", "nonet")
+	if got.Get("error").IsNull() {
+		t.Errorf("verifyBip137() expected error for unknown network")
+	}
+}
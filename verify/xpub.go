@@ -0,0 +1,104 @@
+package verify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// XpubProgressInterval is the number of derived keys between two calls of the
+// progress callback passed to VerifyUnderXpub
+const XpubProgressInterval = 100
+
+// ErrInvalidXpub is returned when an extended public key cannot be parsed
+var ErrInvalidXpub = errors.New("invalid extended public key")
+
+// VerifyUnderXpub checks whether a BIP-0137 signature was made by one of the
+// first limit keys on the external chain (0/i) of an extended public key. It
+// returns the relative derivation path of the matching key, e.g. "0/5".
+//
+// The signing key is recovered once and compared against each derived key, so
+// the result does not depend on the address type the signer used.
+//
+// The scan stops as soon as ctx is done, returning ctx.Err().
+//
+// If progress is not nil it is called with the number of keys scanned so far
+// every XpubProgressInterval keys, and once more when the scan ends.
+func VerifyUnderXpub(ctx context.Context, xpub, message, signatureBase64 string, limit int, params *chaincfg.Params, progress func(scanned int)) (path string, ok bool, err error) {
+	if limit <= 0 {
+		return "", false, fmt.Errorf("invalid scan limit: %d", limit)
+	}
+
+	extendedKey, err := parseXpub(xpub, params)
+	if err != nil {
+		return "", false, err
+	}
+
+	pubKey, _, err := RecoverPubKey(message, signatureBase64)
+	if err != nil {
+		return "", false, err
+	}
+	target := pubKey.SerializeCompressed()
+
+	external, err := extendedKey.Derive(0)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to derive external chain: %w", err)
+	}
+
+	scanned := 0
+	defer func() {
+		if progress != nil {
+			progress(scanned)
+		}
+	}()
+
+	for i := 0; i < limit; i++ {
+		if err := ctx.Err(); err != nil {
+			return "", false, err
+		}
+
+		child, err := external.Derive(uint32(i))
+		if err != nil {
+			// Invalid children are skipped as specified by BIP-32
+			LogDebug("Skipping invalid child 0/%d: %v", i, err)
+			scanned++
+			continue
+		}
+
+		childKey, err := child.ECPubKey()
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read child key 0/%d: %w", i, err)
+		}
+		scanned++
+
+		if bytes.Equal(childKey.SerializeCompressed(), target) {
+			LogInfo("Signature matches key 0/%d", i)
+			return fmt.Sprintf("0/%d", i), true, nil
+		}
+
+		if progress != nil && scanned%XpubProgressInterval == 0 && scanned < limit {
+			progress(scanned)
+		}
+	}
+
+	return "", false, nil
+}
+
+// parseXpub parses an extended public key for the network described by params
+func parseXpub(xpub string, params *chaincfg.Params) (*hdkeychain.ExtendedKey, error) {
+	extendedKey, err := hdkeychain.NewKeyFromString(xpub)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidXpub, err)
+	}
+	if extendedKey.IsPrivate() {
+		return nil, fmt.Errorf("%w: expected a public key, got a private key", ErrInvalidXpub)
+	}
+	if !extendedKey.IsForNet(params) {
+		return nil, fmt.Errorf("%w: key is not for network %s", ErrInvalidXpub, params.Name)
+	}
+	return extendedKey, nil
+}
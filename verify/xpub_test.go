@@ -0,0 +1,114 @@
+package verify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// testXpub returns an account-level xpub from a fixed seed and a signature of
+// message made with the key at 0/index below it
+func testXpub(t *testing.T, message string, index uint32) (string, string) {
+	t.Helper()
+
+	master, err := hdkeychain.NewMaster(bytes.Repeat([]byte{0x01}, 32), &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("NewMaster() error = %v", err)
+	}
+
+	external, err := master.Derive(0)
+	if err != nil {
+		t.Fatalf("Derive() error = %v", err)
+	}
+	child, err := external.Derive(index)
+	if err != nil {
+		t.Fatalf("Derive() error = %v", err)
+	}
+	privKey, err := child.ECPrivKey()
+	if err != nil {
+		t.Fatalf("ECPrivKey() error = %v", err)
+	}
+
+	signature, err := SignBip137Message(privKey, message, true)
+	if err != nil {
+		t.Fatalf("SignBip137Message() error = %v", err)
+	}
+
+	xpub, err := master.Neuter()
+	if err != nil {
+		t.Fatalf("Neuter() error = %v", err)
+	}
+
+	return xpub.String(), signature
+}
+
+func TestVerifyUnderXpub(t *testing.T) {
+	message := "Signed by a wallet key"
+	xpub, signature := testXpub(t, message, 42)
+
+	path, ok, err := VerifyUnderXpub(context.Background(), xpub, message, signature, 100, &chaincfg.MainNetParams, nil)
+	if err != nil || !ok || path != "0/42" {
+		t.Errorf("VerifyUnderXpub() = %q, %v, %v; want \"0/42\", true, nil", path, ok, err)
+	}
+
+	// Out of the scanned range
+	_, ok, err = VerifyUnderXpub(context.Background(), xpub, message, signature, 10, &chaincfg.MainNetParams, nil)
+	if err != nil || ok {
+		t.Errorf("VerifyUnderXpub() = %v, %v; want false, nil", ok, err)
+	}
+
+	if _, _, err := VerifyUnderXpub(context.Background(), "xpubinvalid", message, signature, 10, &chaincfg.MainNetParams, nil); err == nil {
+		t.Errorf("VerifyUnderXpub() expected error for invalid xpub")
+	}
+}
+
+func TestVerifyUnderXpubProgress(t *testing.T) {
+	message := "Signed by a wallet key"
+	xpub, signature := testXpub(t, message, 500)
+
+	const limit = 250
+	var calls []int
+	_, ok, err := VerifyUnderXpub(context.Background(), xpub, message, signature, limit, &chaincfg.MainNetParams, func(scanned int) {
+		calls = append(calls, scanned)
+	})
+	if err != nil || ok {
+		t.Fatalf("VerifyUnderXpub() = %v, %v; want false, nil", ok, err)
+	}
+
+	// Called at each interval and once at the end, not per address
+	want := []int{100, 200, limit}
+	if len(calls) != len(want) {
+		t.Fatalf("progress calls = %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("progress calls = %v, want %v", calls, want)
+			break
+		}
+	}
+}
+
+func TestVerifyUnderXpubCancel(t *testing.T) {
+	message := "Signed by a wallet key"
+	xpub, signature := testXpub(t, message, 5000)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Cancel mid-scan from the first progress report
+	var last int
+	_, ok, err := VerifyUnderXpub(ctx, xpub, message, signature, 100000, &chaincfg.MainNetParams, func(scanned int) {
+		last = scanned
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) || ok {
+		t.Fatalf("VerifyUnderXpub() = %v, %v; want false, %v", ok, err, context.Canceled)
+	}
+	if last != XpubProgressInterval {
+		t.Errorf("scan stopped after %d keys, want %d", last, XpubProgressInterval)
+	}
+}